@@ -20,16 +20,31 @@ package githubactions
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
 	"html/template"
 	"io"
+	"io/fs"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	texttemplate "text/template"
 	"time"
 )
 
@@ -47,8 +62,7 @@ const (
 	stateCmd  = "state"
 
 	// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#multiline-strings
-	multiLineFileDelim = "_GitHubActionsFileCommandDelimeter_"
-	multilineFileCmd   = "%s<<" + multiLineFileDelim + EOF + "%s" + EOF + multiLineFileDelim // ${name}<<${delimiter}${os.EOL}${convertedVal}${os.EOL}${delimiter}
+	multiLineFileDelimPrefix = "ghadelimiter_"
 
 	addMatcherCmd    = "add-matcher"
 	removeMatcherCmd = "remove-matcher"
@@ -56,6 +70,8 @@ const (
 	groupCmd    = "group"
 	endGroupCmd = "endgroup"
 
+	stopCommandsCmd = "stop-commands"
+
 	stepSummaryCmd = "step-summary"
 
 	debugCmd   = "debug"
@@ -66,14 +82,33 @@ const (
 	errFileCmdFmt = "unable to write command to the environment file: %s"
 )
 
+// AnnotationLevel is the severity of an annotation. The value is the name of
+// the workflow command that produces it.
+type AnnotationLevel string
+
+const (
+	AnnotationLevelNotice  AnnotationLevel = noticeCmd
+	AnnotationLevelWarning AnnotationLevel = warningCmd
+	AnnotationLevelError   AnnotationLevel = errorCmd
+)
+
 // New creates a new wrapper with helpers for outputting information in GitHub
 // actions format.
 func New(opts ...Option) *Action {
 	a := &Action{
-		w:      os.Stdout,
-		getenv: os.Getenv,
+		w:       os.Stdout,
+		getenv:  os.Getenv,
+		environ: os.Environ,
+		masks:   &maskSet{},
+		outputs: &outputSet{},
+
+		summarySize: &atomic.Int64{},
+		fileMu:      &sync.Mutex{},
+
+		summaryUnsetOnce: &sync.Once{},
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: &RetryTransport{},
 		},
 	}
 
@@ -84,24 +119,404 @@ func New(opts ...Option) *Action {
 		a = opt(a)
 	}
 
+	if a.requireActionsEnv && a.getenv("GITHUB_ACTIONS") != "true" {
+		a.Fatalf("this action must run in GitHub Actions, but GITHUB_ACTIONS is not \"true\"")
+	}
+
+	if a.startupContextLog {
+		a.logStartupContext()
+	}
+
 	return a
 }
 
+// logStartupContext prints a one-line summary of the workflow context as a
+// debug message. It is a no-op unless debug logging is enabled. The values are
+// read directly from the environment so the event payload is not parsed.
+func (c *Action) logStartupContext() {
+	if !c.IsDebug() {
+		return
+	}
+
+	run := c.getenv("GITHUB_RUN_ID")
+	if attempt := c.getenv("GITHUB_RUN_ATTEMPT"); attempt != "" {
+		run += "/" + attempt
+	}
+
+	c.Debugf("repo=%s ref=%s sha=%s event=%s run=%s",
+		c.getenv("GITHUB_REPOSITORY"),
+		c.getenv("GITHUB_REF"),
+		c.getenv("GITHUB_SHA"),
+		c.getenv("GITHUB_EVENT_NAME"),
+		run)
+}
+
 // Action is an internal wrapper around GitHub Actions' output and magic
 // strings.
 type Action struct {
 	w          io.Writer
 	fields     CommandProperties
 	getenv     GetenvFunc
+	environ    EnvironFunc
 	httpClient *http.Client
+
+	// masks is the set of values registered with AddMask. It is shared between
+	// an action and any actions derived from it.
+	masks *maskSet
+
+	// summarySize is the number of bytes written to the step summary. It is
+	// shared between an action and any actions derived from it.
+	summarySize *atomic.Int64
+
+	// fileMu serializes writes to environment files, such as GITHUB_OUTPUT and
+	// GITHUB_ENV. It is shared between an action and any actions derived from
+	// it.
+	fileMu *sync.Mutex
+
+	// leakDetection enables scanning output for masked values.
+	leakDetection bool
+
+	// recorder, if set, receives a copy of every line written to w.
+	recorder *Recorder
+
+	// sarif, if set, receives a copy of every annotation.
+	sarif *SARIFCollector
+
+	// expressionWarnings enables warnings for inputs that contain literal
+	// expression syntax.
+	expressionWarnings bool
+
+	// eventOverlay is deep-merged into the parsed event payload.
+	eventOverlay map[string]any
+
+	// secretMinLength and secretMinEntropy are the thresholds used by
+	// AddMaskIfSecret. Zero values use the defaults.
+	secretMinLength  int
+	secretMinEntropy float64
+
+	// jsonWriter, if set, receives every issued command as a line of JSON.
+	jsonWriter io.Writer
+
+	// summaryTruncation enables truncating step summaries at a block boundary
+	// when they would exceed the size limit.
+	summaryTruncation bool
+
+	// startupContextLog enables logging a summary of the context in New.
+	startupContextLog bool
+
+	// maxOutputSize is the maximum size of a single output value accepted by
+	// TrySetOutput. Zero uses the default.
+	maxOutputSize int
+
+	// eol is the line ending used when writing output. Empty uses EOF.
+	eol string
+
+	// deprecationWarnings enables warnings when a deprecated workflow command
+	// is issued.
+	deprecationWarnings bool
+
+	// requireActionsEnv makes New exit if not running in GitHub Actions.
+	requireActionsEnv bool
+
+	// idTokenRetryAttempts and idTokenRetryBase configure retries in
+	// GetIDToken. Zero attempts disables retries.
+	idTokenRetryAttempts int
+	idTokenRetryBase     time.Duration
+
+	// clock returns the current time. If nil, time.Now is used.
+	clock func() time.Time
+
+	// tee, if set, receives a copy of everything written to w.
+	tee io.Writer
+
+	// errWriter, if set, receives error and warning commands instead of w.
+	errWriter io.Writer
+
+	// summaryFallback, if set, receives step summaries when
+	// GITHUB_STEP_SUMMARY is not set.
+	summaryFallback io.Writer
+
+	// summaryUnsetOnce ensures the debug message for an unset
+	// GITHUB_STEP_SUMMARY is printed only once. It is shared between an action
+	// and any actions derived from it.
+	summaryUnsetOnce *sync.Once
+
+	// outputs is the set of outputs written with SetOutput and related
+	// methods. It is shared between an action and any actions derived from it.
+	outputs *outputSet
+}
+
+// outputSet is a concurrency-safe record of the outputs set by an action and
+// the outputs it declared with DeclareOutputs.
+type outputSet struct {
+	mu       sync.Mutex
+	values   map[string]string
+	declared []string
+}
+
+// declare records the names as outputs that must be set. Names that are
+// already declared are ignored.
+func (o *outputSet) declare(names ...string) {
+	if o == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, name := range names {
+		if !slices.Contains(o.declared, name) {
+			o.declared = append(o.declared, name)
+		}
+	}
+}
+
+// missing returns the declared outputs that have not been set, in the order
+// they were declared.
+func (o *outputSet) missing() []string {
+	if o == nil {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var missing []string
+	for _, name := range o.declared {
+		if _, ok := o.values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// set records the output. Setting an output again replaces the value.
+func (o *outputSet) set(k, v string) {
+	if o == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.values == nil {
+		o.values = make(map[string]string)
+	}
+	o.values[k] = v
+}
+
+// sorted returns the recorded output names in sorted order and their values.
+func (o *outputSet) sorted() ([]string, map[string]string) {
+	if o == nil {
+		return nil, nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	keys := make([]string, 0, len(o.values))
+	values := make(map[string]string, len(o.values))
+	for k, v := range o.values {
+		keys = append(keys, k)
+		values[k] = v
+	}
+	sort.Strings(keys)
+	return keys, values
+}
+
+// maskSet is a concurrency-safe collection of masked values.
+type maskSet struct {
+	mu sync.RWMutex
+
+	// values is sorted by length, longest first, so a value that contains
+	// another masked value is redacted before the shorter one.
+	values []string
+}
+
+// add registers the value as masked.
+func (m *maskSet) add(v string) {
+	if m == nil || v == "" {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := sort.Search(len(m.values), func(i int) bool {
+		return len(m.values[i]) < len(v)
+	})
+	m.values = slices.Insert(m.values, i, v)
+}
+
+// contains returns true if the value was registered as masked.
+func (m *maskSet) contains(v string) bool {
+	if m == nil {
+		return false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, mv := range m.values {
+		if mv == v {
+			return true
+		}
+	}
+	return false
+}
+
+// redact replaces all occurrences of masked values in s with "***", longest
+// first. Values are matched both verbatim and in their escaped command form. It
+// returns true if any value was replaced.
+func (m *maskSet) redact(s string) (string, bool) {
+	if m == nil {
+		return s, false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var found bool
+	for _, mv := range m.values {
+		for _, v := range []string{mv, escapeData(mv)} {
+			if strings.Contains(s, v) {
+				s = strings.ReplaceAll(s, v, "***")
+				found = true
+			}
+		}
+	}
+	return s, found
+}
+
+// secretLeakWarning is the message emitted when leak detection redacts output.
+const secretLeakWarning = "Detected a masked value in output; it has been redacted"
+
+// guard redacts values registered with AddMask from s, so they are not written
+// to the output stream even when it is not processed by the runner. If leak
+// detection is enabled and a masked value was found, a warning is written to
+// the output stream first.
+func (c *Action) guard(s string) (string, error) {
+	s, found := c.masks.redact(s)
+	if found && c.leakDetection {
+		warn := &Command{Name: warningCmd, Message: secretLeakWarning}
+		if err := c.emit(warn.String()); err != nil {
+			return "", err
+		}
+	}
+	return s, nil
+}
+
+// emit writes the line to the output stream, followed by an OS-specific line
+// break, and to the tee writer if one is configured. It records the line if a
+// recorder is configured.
+func (c *Action) emit(line string) error {
+	return c.emitTo(c.w, line)
+}
+
+// emitTo is like emit, but writes the line to w instead of the output stream.
+func (c *Action) emitTo(w io.Writer, line string) error {
+	if _, err := fmt.Fprint(w, line+c.lineEnding()); err != nil {
+		return err
+	}
+	if c.tee != nil {
+		if _, err := fmt.Fprint(c.tee, line+c.lineEnding()); err != nil {
+			return fmt.Errorf("failed to write to tee: %w", err)
+		}
+	}
+	c.recorder.record(line)
+	return nil
+}
+
+// commandWriter returns the writer for the command with the given name. Error
+// and warning commands are written to the error writer, if one is configured.
+// All other commands are written to the output stream.
+func (c *Action) commandWriter(name string) io.Writer {
+	if c.errWriter != nil && (name == errorCmd || name == warningCmd) {
+		return c.errWriter
+	}
+	return c.w
 }
 
 // IssueCommand issues a new GitHub actions Command. It panics if it cannot
-// write to the output stream.
+// write to the output stream. Use IssueCommandErr to handle write failures.
+//
+// Values registered with AddMask are replaced with "***" in the command
+// message. The command name and properties are never redacted, so a short
+// masked value cannot corrupt the command. If secret leak detection is enabled,
+// a warning is also emitted.
 func (c *Action) IssueCommand(cmd *Command) {
-	if _, err := fmt.Fprint(c.w, cmd.String()+EOF); err != nil {
-		panic(fmt.Errorf("failed to issue command: %w", err))
+	if err := c.IssueCommandErr(cmd); err != nil {
+		panic(err)
+	}
+}
+
+// IssueCommandErr issues a new GitHub actions Command like IssueCommand, but
+// returns an error instead of panicking if it cannot write to the output
+// stream.
+func (c *Action) IssueCommandErr(cmd *Command) error {
+	if c.deprecationWarnings {
+		if repl, ok := deprecatedCommands[cmd.Name]; ok {
+			if err := c.IssueCommandErr(&Command{
+				Name: warningCmd,
+				Message: fmt.Sprintf("The %q command is deprecated and disabled by the runner, "+
+					"use %s instead", cmd.Name, repl),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	out := *cmd
+	if cmd.Name != addMaskCmd {
+		var err error
+		if out.Message, err = c.guard(cmd.Message); err != nil {
+			return fmt.Errorf("failed to issue command: %w", err)
+		}
+	}
+	s := out.String()
+
+	if err := c.emitTo(c.commandWriter(cmd.Name), s); err != nil {
+		return fmt.Errorf("failed to issue command: %w", err)
+	}
+
+	if c.jsonWriter != nil {
+		if err := c.writeCommandJSON(cmd); err != nil {
+			return fmt.Errorf("failed to write command JSON: %w", err)
+		}
+	}
+	return nil
+}
+
+// deprecatedCommands maps the deprecated stdout workflow commands to the
+// methods that replace them.
+//
+// https://github.blog/changelog/2020-10-01-github-actions-deprecating-set-env-and-add-path-commands/
+var deprecatedCommands = map[string]string{
+	"set-env":  "SetEnv",
+	"add-path": "AddPath",
+}
+
+// commandJSON is the JSON representation of a Command written by
+// WithCommandJSONWriter.
+type commandJSON struct {
+	Name       string            `json:"name"`
+	Properties map[string]string `json:"properties,omitempty"`
+	Message    string            `json:"message"`
+}
+
+// writeCommandJSON writes the command as a single line of JSON to the JSON
+// writer. Values registered with AddMask are redacted from the message.
+func (c *Action) writeCommandJSON(cmd *Command) error {
+	msg := cmd.Message
+	if cmd.Name != addMaskCmd {
+		msg, _ = c.masks.redact(msg)
+	}
+
+	b, err := json.Marshal(&commandJSON{
+		Name:       cmd.Name,
+		Properties: cmd.Properties,
+		Message:    msg,
+	})
+	if err != nil {
+		return err
 	}
+
+	_, err = fmt.Fprint(c.jsonWriter, string(b)+"\n")
+	return err
 }
 
 // IssueFileCommand issues a new GitHub actions Command using environment files.
@@ -122,15 +537,29 @@ func (c *Action) IssueFileCommand(cmd *Command) {
 	}
 }
 
+// lockFiles locks the mutex guarding environment file writes and returns the
+// func that unlocks it. It is a no-op if the action has no mutex, such as when
+// it was not created with New.
+func (c *Action) lockFiles() func() {
+	if c.fileMu == nil {
+		return func() {}
+	}
+	c.fileMu.Lock()
+	return c.fileMu.Unlock
+}
+
 // issueFileCommand is an internal-only helper that issues the command and
-// returns an error to make testing easier.
+// returns an error to make testing easier. Writes are serialized so that
+// concurrent callers cannot interleave partial entries.
 func (c *Action) issueFileCommand(cmd *Command) (retErr error) {
+	defer c.lockFiles()()
+
 	e := strings.ReplaceAll(cmd.Name, "-", "_")
 	e = strings.ToUpper(e)
 	e = "GITHUB_" + e
 
 	filepath := c.getenv(e)
-	msg := []byte(cmd.Message + EOF)
+	msg := []byte(cmd.Message + c.lineEnding())
 	f, err := os.OpenFile(filepath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		retErr = fmt.Errorf(errFileCmdFmt, err)
@@ -150,10 +579,93 @@ func (c *Action) issueFileCommand(cmd *Command) (retErr error) {
 	return
 }
 
+// issueMultilineFileCommand issues a file command of the given name that sets
+// the key to the value using the multiline delimiter syntax.
+func (c *Action) issueMultilineFileCommand(name, k, v string) error {
+	msg, err := c.multilineFileCommand(k, v)
+	if err != nil {
+		return err
+	}
+	if err := c.issueFileCommand(&Command{
+		Name:    name,
+		Message: msg,
+	}); err != nil {
+		return err
+	}
+
+	if name == outputCmd {
+		c.outputs.set(k, v)
+	}
+	return nil
+}
+
+// multilineFileCommand formats the key and value for an environment file using
+// the multiline delimiter syntax with a random delimiter:
+//
+//	${name}<<${delimiter}${os.EOL}${convertedVal}${os.EOL}${delimiter}
+//
+// The runner reads the lines between the delimiters and joins them with line
+// breaks, so the value round-trips exactly, including a trailing newline. It
+// returns an error if the key or value contains the delimiter, which would
+// otherwise allow the value to inject additional entries into the file.
+func (c *Action) multilineFileCommand(k, v string) (string, error) {
+	if err := validateFileCommandKey(k); err != nil {
+		return "", err
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate delimiter: %w", err)
+	}
+	delim := multiLineFileDelimPrefix + token
+
+	if strings.Contains(k, delim) {
+		return "", fmt.Errorf("name %q must not contain the delimiter %q", k, delim)
+	}
+	if strings.Contains(v, delim) {
+		return "", fmt.Errorf("value for %q must not contain the delimiter %q", k, delim)
+	}
+
+	eol := c.lineEnding()
+	return k + "<<" + delim + eol + v + eol + delim, nil
+}
+
+// validateFileCommandKey returns an error if the key would produce an
+// environment file entry that the runner cannot parse: it must be non-empty and
+// must not contain "=", line breaks, or the delimiter prefix.
+func validateFileCommandKey(k string) error {
+	if k == "" {
+		return fmt.Errorf("invalid name %q: cannot be empty", k)
+	}
+	if strings.Contains(k, "=") {
+		return fmt.Errorf("invalid name %q: cannot contain \"=\"", k)
+	}
+	if strings.ContainsAny(k, "\r\n") {
+		return fmt.Errorf("invalid name %q: cannot contain line breaks", k)
+	}
+	if strings.Contains(k, multiLineFileDelimPrefix) {
+		return fmt.Errorf("invalid name %q: cannot contain the delimiter prefix %q", k, multiLineFileDelimPrefix)
+	}
+	return nil
+}
+
+// lineEnding returns the line ending used when writing output, which is EOF
+// unless changed with WithEOL.
+func (c *Action) lineEnding() string {
+	if c.eol == "" {
+		return EOF
+	}
+	return c.eol
+}
+
 // AddMask adds a new field mask for the given string "p". After called, future
-// attempts to log "p" will be replaced with "***" in log output. It panics if
+// attempts to log "p" will be replaced with "***" in log output. The value is
+// also redacted from the messages and text the action subsequently writes to its
+// own output stream, which protects output captured outside of the runner. It panics if
 // it cannot write to the output stream.
 func (c *Action) AddMask(p string) {
+	c.masks.add(p)
+
 	// ::add-mask::<p>
 	c.IssueCommand(&Command{
 		Name:    addMaskCmd,
@@ -161,6 +673,75 @@ func (c *Action) AddMask(p string) {
 	})
 }
 
+const (
+	// defaultSecretMinLength is the default minimum length of a value for
+	// AddMaskIfSecret to consider it a secret.
+	defaultSecretMinLength = 16
+
+	// defaultSecretMinEntropy is the default minimum Shannon entropy, in bits
+	// per character, of a value for AddMaskIfSecret to consider it a secret.
+	defaultSecretMinEntropy = 3.5
+)
+
+// AddMaskIfSecret masks the given string only if it looks like a secret: it
+// must be at least the minimum length and have at least the minimum Shannon
+// entropy per character. This avoids masking common words that would otherwise
+// be redacted throughout the log. The thresholds default to 16 characters and
+// 3.5 bits per character, and can be changed with WithSecretHeuristic. It
+// returns true if the value was masked. It panics if it cannot write to the
+// output stream.
+func (c *Action) AddMaskIfSecret(s string) bool {
+	minLength, minEntropy := c.secretMinLength, c.secretMinEntropy
+	if minLength <= 0 {
+		minLength = defaultSecretMinLength
+	}
+	if minEntropy <= 0 {
+		minEntropy = defaultSecretMinEntropy
+	}
+
+	if len(s) < minLength || shannonEntropy(s) < minEntropy {
+		return false
+	}
+
+	c.AddMask(s)
+	return true
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	var n int
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(n)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// FlushMasks flushes the output stream so that any masks registered with
+// AddMask reach the runner before subsequent output. The writer is flushed if
+// it implements "Flush() error" (such as a *bufio.Writer). The runner processes
+// commands asynchronously, so this is best-effort and does not guarantee the
+// mask is applied before the next line is logged. It panics if the writer
+// cannot be flushed.
+func (c *Action) FlushMasks() {
+	if f, ok := c.w.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			panic(fmt.Errorf("failed to flush output: %w", err))
+		}
+	}
+}
+
 // AddMatcher adds a new matcher with the given file path. It panics if it
 // cannot write to the output stream.
 func (c *Action) AddMatcher(p string) {
@@ -196,103 +777,1301 @@ func (c *Action) AddPath(p string) {
 }
 
 // SaveState saves state to be used in the "finally" post job entry point. It
-// panics if it cannot write to the output stream.
+// panics if the name is invalid or it cannot write to the state file. Use
+// SaveStateErr to handle errors.
 //
 // On 2022-10-11, GitHub deprecated "::save-state name=<k>::<v>" in favor of
 // [environment files].
 //
 // [environment files]: https://github.blog/changelog/2022-10-11-github-actions-deprecating-save-state-and-set-output-commands/
 func (c *Action) SaveState(k, v string) {
-	c.IssueFileCommand(&Command{
-		Name:    stateCmd,
-		Message: fmt.Sprintf(multilineFileCmd, k, v),
-	})
+	if err := c.SaveStateErr(k, v); err != nil {
+		panic(err)
+	}
 }
 
-// GetInput gets the input by the given name. It returns the empty string if the
-// input is not defined.
-func (c *Action) GetInput(i string) string {
-	e := strings.ReplaceAll(i, " ", "_")
-	e = strings.ToUpper(e)
-	e = "INPUT_" + e
-	return strings.TrimSpace(c.getenv(e))
+// SaveStateErr saves state like SaveState, but returns an error instead of
+// panicking. It returns an error if the name is empty or contains "=", line
+// breaks, or the delimiter prefix, or if it cannot write to the state file.
+func (c *Action) SaveStateErr(k, v string) error {
+	if err := c.issueMultilineFileCommand(stateCmd, k, v); err != nil {
+		return fmt.Errorf("failed to save state %q: %w", k, err)
+	}
+	return nil
 }
 
-// Group starts a new collapsable region up to the next ungroup invocation. It
-// panics if it cannot write to the output stream.
-func (c *Action) Group(t string) {
-	// ::group::<t>
-	c.IssueCommand(&Command{
-		Name:    groupCmd,
-		Message: t,
-	})
+// GetState gets the state saved with SaveState by the given name, typically in
+// a post step. The runner exposes state as "STATE_<name>" with the name's case
+// preserved; if that is not set, the uppercased name is tried for consistency
+// with GetInput. Surrounding whitespace is trimmed. It returns the empty string
+// if the state is not defined.
+func (c *Action) GetState(name string) string {
+	v := c.getenv("STATE_" + name)
+	if v == "" {
+		if upper := strings.ToUpper(name); upper != name {
+			v = c.getenv("STATE_" + upper)
+		}
+	}
+	return strings.TrimSpace(v)
 }
 
-// EndGroup ends the current group. It panics if it cannot write to the output
-// stream.
-func (c *Action) EndGroup() {
-	// ::endgroup::
-	c.IssueCommand(&Command{
-		Name: endGroupCmd,
-	})
-}
+// expressionMarker is the opening sequence of a GitHub Actions expression.
+const expressionMarker = "${{"
 
-// AddStepSummary writes the given markdown to the job summary. If a job summary
-// already exists, this value is appended.
+// GetInput gets the input by the given name. It returns the empty string if the
+// input is not defined.
 //
-// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#adding-a-job-summary
-// https://github.blog/2022-05-09-supercharging-github-actions-with-job-summaries/
-func (c *Action) AddStepSummary(markdown string) {
-	c.IssueFileCommand(&Command{
-		Name:    stepSummaryCmd,
-		Message: markdown,
-	})
-}
-
-// AddStepSummaryTemplate adds a summary template by parsing the given Go
-// template using html/template with the given input data. See AddStepSummary
-// for caveats.
+// The runner exposes each input as an environment variable named "INPUT_"
+// followed by the input name with spaces replaced by underscores and converted
+// to uppercase; hyphens are kept. GetInput first looks up the variable for the
+// name exactly as given using the same rules. If that is empty, it tries the
+// name with every hyphen and space replaced by an underscore, and then with
+// every underscore and space replaced by a hyphen. This means "my input",
+// "my-input", and "my_input" all find an input declared with any of those
+// names. Surrounding whitespace in the name is ignored.
 //
-// This primarily exists as a convenience function that renders a template.
-func (c *Action) AddStepSummaryTemplate(tmpl string, data any) error {
-	t, err := template.New("").Parse(tmpl)
-	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+// If expression warnings are enabled, a warning is emitted when the value
+// contains a literal "${{", which usually means the workflow did not evaluate
+// an expression.
+func (c *Action) GetInput(i string) string {
+	var v string
+	for _, e := range inputEnvKeys(i) {
+		if v = strings.TrimSpace(c.getenv(e)); v != "" {
+			break
+		}
 	}
 
-	var b bytes.Buffer
-	if err := t.Execute(&b, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+	if c.expressionWarnings && strings.Contains(v, expressionMarker) {
+		c.IssueCommand(&Command{
+			Name:    warningCmd,
+			Message: fmt.Sprintf("Input %q contains a literal %q, which usually means an expression was not evaluated by the workflow", i, expressionMarker),
+		})
 	}
-
-	c.AddStepSummary(b.String())
-	return nil
+	return v
 }
 
-// SetEnv sets an environment variable. It panics if it cannot write to the
-// output file.
-//
-// https://docs.github.com/en/free-pro-team@latest/actions/reference/workflow-commands-for-github-actions#setting-an-environment-variable
-// https://github.blog/changelog/2020-10-01-github-actions-deprecating-set-env-and-add-path-commands/
-func (c *Action) SetEnv(k, v string) {
-	c.IssueFileCommand(&Command{
-		Name:    envCmd,
-		Message: fmt.Sprintf(multilineFileCmd, k, v),
+// inputEnvKeys returns the environment variable names to check, in order, for
+// the input with the given name. See GetInput for the normalization rules.
+func inputEnvKeys(name string) []string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+
+	exact := "INPUT_" + strings.ReplaceAll(name, " ", "_")
+	underscores := "INPUT_" + strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+	hyphens := "INPUT_" + strings.NewReplacer(" ", "-", "_", "-").Replace(name)
+
+	keys := []string{exact}
+	for _, k := range []string{underscores, hyphens} {
+		if k != keys[len(keys)-1] && k != exact {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// GetInputFirst returns the value of the first input among names that is not
+// empty, along with the name that provided it. This is useful when an input is
+// renamed and both the old and new names are supported, such as to warn when
+// the deprecated name is used. It returns two empty strings if none of the
+// inputs are set.
+func (c *Action) GetInputFirst(names ...string) (value, which string) {
+	for _, name := range names {
+		if v := c.GetInput(name); v != "" {
+			return v, name
+		}
+	}
+	return "", ""
+}
+
+// GetRequiredInput gets the input by the given name. It returns an error if the
+// input is not defined or is empty after trimming whitespace, mirroring the
+// "required" option of getInput in @actions/core.
+func (c *Action) GetRequiredInput(i string) (string, error) {
+	v := c.GetInput(i)
+	if v == "" {
+		return "", fmt.Errorf("input %q is required but was not provided", i)
+	}
+	return v, nil
+}
+
+// GetMultilineInput gets the input by the given name and splits it into lines.
+// Each line is trimmed of surrounding whitespace (including "\r" from Windows
+// line endings) and empty lines are dropped. It returns an empty, non-nil slice
+// if the input is not defined.
+func (c *Action) GetMultilineInput(i string) []string {
+	return splitInput(c.GetInput(i), "\n")
+}
+
+// GetCSVInput gets the input by the given name and splits it on commas. Each
+// value is trimmed of surrounding whitespace and empty values are dropped. It
+// returns an empty, non-nil slice if the input is not defined. Quoting is not
+// supported.
+func (c *Action) GetCSVInput(i string) []string {
+	return splitInput(c.GetInput(i), ",")
+}
+
+// splitInput splits v on sep, trimming each entry and dropping empty entries.
+func splitInput(v, sep string) []string {
+	parts := strings.Split(v, sep)
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// GetBool gets the input by the given name and parses it as a boolean using
+// the YAML 1.1 truthy set: "true", "yes", "on", and "1" are true; "false",
+// "no", "off", and "0" are false (case-insensitive). It returns false if the
+// input is not defined, and an error for any other value.
+//
+// This is the set UnmarshalInputs uses for bool fields. It differs from
+// GetBoolInput, which accepts "t" and "f" but not "yes", "no", "on", or "off",
+// and from GetBoolInputStrict, which accepts only "true" and "false".
+func (c *Action) GetBool(i string) (bool, error) {
+	v := c.GetInput(i)
+	b, err := parseYAMLBool(v)
+	if err != nil {
+		return false, fmt.Errorf("input %q is not a valid boolean: %q", i, v)
+	}
+	return b, nil
+}
+
+// GetInt gets the input by the given name and parses it as a base-10 integer.
+// It returns 0 if the input is not defined, and an error if the value is not an
+// integer.
+func (c *Action) GetInt(i string) (int64, error) {
+	v := c.GetInput(i)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("input %q is not a valid integer: %q", i, v)
+	}
+	return n, nil
+}
+
+// GetIntInputInRange gets the required input by the given name and parses it as
+// a base-10 integer between min and max, inclusive. It returns an error naming
+// the input and the allowed range if the input is not defined, is not an
+// integer, or is out of range.
+func (c *Action) GetIntInputInRange(i string, min, max int64) (int64, error) {
+	v := c.GetInput(i)
+	if v == "" {
+		return 0, fmt.Errorf("input %q is required and must be an integer between %d and %d", i, min, max)
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("input %q must be an integer between %d and %d: %q", i, min, max, v)
+	}
+	if n < min || n > max {
+		return 0, fmt.Errorf("input %q must be between %d and %d: %d", i, min, max, n)
+	}
+	return n, nil
+}
+
+// GetInputSemver gets the required input by the given name and parses it as a
+// semantic version of the form "major.minor.patch" with an optional
+// prerelease, such as "1.2.3" or "v1.2.3-rc.1". It returns an error wrapping
+// ErrEmptyInput if the input is not defined, and an error naming the input if
+// the value is not a valid version.
+func (c *Action) GetInputSemver(i string) (*Version, error) {
+	v := c.GetInput(i)
+	if v == "" {
+		return nil, fmt.Errorf("input %q: %w", i, ErrEmptyInput)
+	}
+
+	ver, err := parseVersion(v)
+	if err != nil {
+		return nil, fmt.Errorf("input %q must be a semantic version: %q: %w", i, v, err)
+	}
+	return ver, nil
+}
+
+// GetFloat gets the input by the given name and parses it as a floating point
+// number. It returns 0 if the input is not defined, and an error if the value
+// is not a number.
+func (c *Action) GetFloat(i string) (float64, error) {
+	v := c.GetInput(i)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("input %q is not a valid number: %q", i, v)
+	}
+	return n, nil
+}
+
+// GetDuration gets the input by the given name and parses it with
+// time.ParseDuration, such as "30s" or "1h30m". It returns 0 if the input is
+// not defined, and an error if the value is not a valid duration.
+func (c *Action) GetDuration(i string) (time.Duration, error) {
+	v := c.GetInput(i)
+	if v == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("input %q is not a valid duration: %q", i, v)
+	}
+	return d, nil
+}
+
+// GetBoolInput gets the input by the given name and parses it with
+// strconv.ParseBool: "1", "t", "T", "TRUE", "true", and "True" are true; "0",
+// "f", "F", "FALSE", "false", and "False" are false. It returns false if the
+// input is not defined, and an error for any other value.
+//
+// Unlike GetBool, which also backs UnmarshalInputs, it does not accept YAML
+// values such as "yes", "no", "on", or "off", nor mixed case such as "tRuE".
+// See also GetBoolInputStrict.
+func (c *Action) GetBoolInput(i string) (bool, error) {
+	v := c.GetInput(i)
+	b, err := parseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("input %q is not a valid boolean: %q", i, v)
+	}
+	return b, nil
+}
+
+// GetBoolInputStrict gets the input by the given name and parses it as a
+// boolean. Unlike GetBoolInput, only the lowercase values "true" and "false"
+// are accepted, matching the boolean type in action.yml. It returns false if
+// the input is not defined, and an error for any other value.
+func (c *Action) GetBoolInputStrict(i string) (bool, error) {
+	switch v := c.GetInput(i); v {
+	case "":
+		return false, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("input %q is not a valid boolean: must be \"true\" or \"false\", got %q", i, v)
+	}
+}
+
+// inputTag is the struct tag used by UnmarshalInputs.
+const inputTag = "actions"
+
+// durationType is the reflect.Type of time.Duration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// UnmarshalInputs populates the fields of the struct pointed to by v from the
+// action's inputs. Each field to populate must be exported and have an
+// "actions" struct tag with the input name, such as:
+//
+//	var inputs struct {
+//		Token   string        `actions:"token"`
+//		DryRun  bool          `actions:"dry-run"`
+//		Retries int           `actions:"retries"`
+//		Timeout time.Duration `actions:"timeout"`
+//	}
+//
+// Inputs are read with GetInput, so the same name normalization applies.
+// String fields are set to the value as-is. Bool fields are parsed like GetBool
+// (not GetBoolInput), integer fields like GetInt, and time.Duration fields like
+// GetDuration. Fields
+// whose input is not defined are left unchanged, so defaults can be set before
+// calling UnmarshalInputs. Fields without the tag, or with the tag "-", are
+// ignored.
+//
+// It returns an error if v is not a non-nil pointer to a struct, or describing
+// every field that could not be set, such as one with an unsupported type or an
+// invalid value.
+func (c *Action) UnmarshalInputs(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("failed to unmarshal inputs: expected a non-nil pointer to a struct, got %T", v)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	var merr error
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, ok := field.Tag.Lookup(inputTag)
+		if !ok || name == "-" {
+			continue
+		}
+		if !field.IsExported() {
+			merr = errors.Join(merr, fmt.Errorf("field %s for input %q is not exported", field.Name, name))
+			continue
+		}
+
+		if err := c.setInputField(rv.Field(i), name); err != nil {
+			merr = errors.Join(merr, fmt.Errorf("field %s: %w", field.Name, err))
+		}
+	}
+	return merr
+}
+
+// setInputField sets the field to the value of the input with the given name.
+// The field is not changed if the input is not defined.
+func (c *Action) setInputField(fv reflect.Value, name string) error {
+	v := c.GetInput(name)
+	if v == "" {
+		return nil
+	}
+
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("input %q is not a valid duration: %q", name, v)
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(v)
+	case reflect.Bool:
+		b, err := parseYAMLBool(v)
+		if err != nil {
+			return fmt.Errorf("input %q is not a valid boolean: %q", name, v)
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("input %q is not a valid integer: %q", name, v)
+		}
+		if fv.OverflowInt(n) {
+			return fmt.Errorf("input %q is out of range for %s: %d", name, fv.Type(), n)
+		}
+		fv.SetInt(n)
+	default:
+		return fmt.Errorf("unsupported type %s for input %q", fv.Type(), name)
+	}
+	return nil
+}
+
+// GetInputTime gets the input by the given name and parses it as a time using
+// the given layout. If layout is empty, time.RFC3339 is used. It returns the
+// zero time if the input is not defined, and an error if the value cannot be
+// parsed.
+func (c *Action) GetInputTime(i, layout string) (time.Time, error) {
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	v := c.GetInput(i)
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(layout, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("input %q is not a valid time: %w", i, err)
+	}
+	return t, nil
+}
+
+// GetSecret gets the input by the given name like GetInput, but registers the
+// value with AddMask before returning it, so it is redacted from the logs. It
+// returns the empty string, without masking, if the input is not defined.
+func (c *Action) GetSecret(i string) string {
+	v := c.GetInput(i)
+	if v != "" {
+		c.AddMask(v)
+	}
+	return v
+}
+
+// GetSecretsInput gets the input by the given name and decodes it as a JSON
+// object of secrets, such as one produced by "${{ toJSON(secrets) }}". Every
+// non-empty value is registered with AddMask, in sorted order by key, before
+// it is returned. It returns an empty map if the input is not defined, and an
+// error if the value is not a JSON object of strings.
+func (c *Action) GetSecretsInput(i string) (map[string]string, error) {
+	v := c.GetInput(i)
+	if v == "" {
+		return map[string]string{}, nil
+	}
+
+	var secrets map[string]string
+	if err := json.Unmarshal([]byte(v), &secrets); err != nil {
+		return nil, fmt.Errorf("failed to decode input %q as JSON: %w", i, err)
+	}
+
+	keys := make([]string, 0, len(secrets))
+	for k := range secrets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if secrets[k] != "" {
+			c.AddMask(secrets[k])
+		}
+	}
+	return secrets, nil
+}
+
+// LogInputs prints all inputs the action received inside a collapsed group. The
+// input names are printed without the "INPUT_" prefix and in lowercase. The
+// values of inputs that were registered with AddMask are redacted. This is a
+// no-op unless debug logging is enabled on the runner. It panics if it cannot
+// write to the output stream.
+func (c *Action) LogInputs() {
+	if !c.IsDebug() || c.environ == nil {
+		return
+	}
+
+	inputs := make(map[string]string)
+	for _, kv := range c.environ() {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(k, "INPUT_") {
+			continue
+		}
+		inputs[strings.ToLower(strings.TrimPrefix(k, "INPUT_"))] = v
+	}
+
+	names := make([]string, 0, len(inputs))
+	for k := range inputs {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	c.Group("Inputs")
+	for _, k := range names {
+		v := inputs[k]
+		if c.masks.contains(strings.TrimSpace(v)) || c.masks.contains(v) {
+			v = "***"
+		}
+		c.Infof("%s=%s", k, v)
+	}
+	c.EndGroup()
+}
+
+// defaultEnvSnapshotPrefixes are the prefixes used by EnvSnapshot when none are
+// given.
+var defaultEnvSnapshotPrefixes = []string{"GITHUB_", "RUNNER_", "INPUT_"}
+
+// EnvSnapshot returns the environment variables whose names start with any of
+// the given prefixes, which is useful for reproducing issues. If no prefixes are
+// given, "GITHUB_", "RUNNER_", and "INPUT_" are used. Values registered with
+// AddMask are redacted. It returns an empty map if the environment is not
+// available.
+func (c *Action) EnvSnapshot(prefixes ...string) map[string]string {
+	if len(prefixes) == 0 {
+		prefixes = defaultEnvSnapshotPrefixes
+	}
+
+	snapshot := make(map[string]string)
+	if c.environ == nil {
+		return snapshot
+	}
+
+	for _, kv := range c.environ() {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(k, prefix) {
+				snapshot[k], _ = c.masks.redact(v)
+				break
+			}
+		}
+	}
+	return snapshot
+}
+
+// IsDebug returns true if debug logging is enabled on the runner, which is when
+// the RUNNER_DEBUG environment variable is "1". Use it to skip expensive work
+// that only produces debug output.
+func (c *Action) IsDebug() bool {
+	return c.getenv("RUNNER_DEBUG") == "1"
+}
+
+// Group starts a new collapsable region up to the next ungroup invocation. It
+// panics if it cannot write to the output stream.
+func (c *Action) Group(t string) {
+	// ::group::<t>
+	c.IssueCommand(&Command{
+		Name:    groupCmd,
+		Message: t,
+	})
+}
+
+// EndGroup ends the current group. It panics if it cannot write to the output
+// stream.
+func (c *Action) EndGroup() {
+	// ::endgroup::
+	c.IssueCommand(&Command{
+		Name: endGroupCmd,
 	})
 }
 
-// SetOutput sets an output parameter. It panics if it cannot write to the
-// output stream.
-//
-// On 2022-10-11, GitHub deprecated "::set-output name=<k>::<v>" in favor of
-// [environment files].
+// durationOutputName is the name of the output set by the func returned from
+// StartTimer.
+const durationOutputName = "duration_ms"
+
+// StartTimer starts timing the action and returns a func that stops the timer.
+// When called, the stop func sets the elapsed time in whole milliseconds as the
+// "duration_ms" output and logs it. The time is read from the clock set with
+// WithClock. The stop func panics if it cannot write the output or the log.
+func (c *Action) StartTimer() func() {
+	start := c.now()
+	return func() {
+		d := c.now().Sub(start)
+		c.SetOutput(durationOutputName, strconv.FormatInt(d.Milliseconds(), 10))
+		c.Infof("Completed in %s", d)
+	}
+}
+
+// now returns the current time from the action's clock.
+func (c *Action) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock()
+}
+
+// GroupFunc runs fn inside a collapsed group with the given title. The group is
+// always closed, even if fn panics, in which case the panic continues after the
+// group is closed. It panics if it cannot write to the output stream.
+func (c *Action) GroupFunc(title string, fn func()) {
+	c.Group(title)
+	defer c.EndGroup()
+	fn()
+}
+
+// Step runs fn inside a collapsed group with the given name. The group is always
+// closed, even if fn panics. If fn returns an error, it is reported as an
+// error-level message after the group is closed and then returned. It panics if
+// it cannot write to the output stream.
+func (c *Action) Step(name string, fn func() error) error {
+	err := func() error {
+		c.Group(name)
+		defer c.EndGroup()
+		return fn()
+	}()
+
+	if err != nil {
+		c.Errorf("%s", err)
+	}
+	return err
+}
+
+// LogBlock prints the content inside a collapsed group with the given title.
+// Workflow command processing is paused while the content is printed, so lines
+// that look like commands (such as "::error::") are logged verbatim instead of
+// being interpreted by the runner. It panics if it cannot write to the output
+// stream.
+//
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#stopping-and-starting-workflow-commands
+func (c *Action) LogBlock(title, content string) {
+	token, err := randomToken()
+	if err != nil {
+		panic(fmt.Errorf("failed to generate stop-commands token: %w", err))
+	}
+
+	c.Group(title)
+
+	// ::stop-commands::<token>
+	c.IssueCommand(&Command{
+		Name:    stopCommandsCmd,
+		Message: token,
+	})
+
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		c.Infof("%s", line)
+	}
+
+	// ::<token>::
+	c.IssueCommand(&Command{
+		Name: token,
+	})
+
+	c.EndGroup()
+}
+
+// ReportTestFailure reports a failed test, typically from a test-reporter
+// action. It emits an error annotation titled with the test name, followed by
+// the captured output in a collapsed group printed with LogBlock, so lines in
+// the output that look like workflow commands are not interpreted. Any fields
+// set on the action, such as "file" and "line", are included in the
+// annotation. It panics if it cannot write to the output stream.
+func (c *Action) ReportTestFailure(name, output string) {
+	c.AnnotateError(Annotation{Title: name}, fmt.Sprintf("Test %s failed", name))
+	c.LogBlock(name, output)
+}
+
+// randomToken returns a random hex string suitable for use as a stop-commands
+// token or a file command delimiter.
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// AddStepSummary writes the given markdown to the job summary. If a job summary
+// already exists, this value is appended. It panics if the summary would exceed
+// the 1 MiB limit or it cannot write to the file. Use AddStepSummaryErr to
+// handle these errors.
+//
+// If summary truncation is enabled and the summary would exceed the 1 MiB
+// limit, the markdown is truncated at the last complete block and a note is
+// appended. If the summary is too full to fit even the note, nothing is written.
+// See WithStepSummaryTruncation.
+//
+// If GITHUB_STEP_SUMMARY is not set, such as on older runners or when running
+// locally, the markdown is written to the writer set with
+// WithStepSummaryFallback. If no fallback is set, the markdown is discarded and
+// a debug message is printed the first time this happens.
+//
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#adding-a-job-summary
+// https://github.blog/2022-05-09-supercharging-github-actions-with-job-summaries/
+func (c *Action) AddStepSummary(markdown string) {
+	if c.summaryTruncation {
+		max := int(stepSummaryLimit-c.stepSummarySize()) - len(c.lineEnding())
+		if len(markdown) > max && max <= len(truncatedNote) {
+			return
+		}
+		markdown = truncateMarkdown(markdown, max)
+	}
+
+	if err := c.AddStepSummaryErr(markdown); err != nil {
+		panic(err)
+	}
+}
+
+// AddStepSummaryErr writes the given markdown to the job summary like
+// AddStepSummary, but returns an error instead of panicking. It returns an
+// error, without writing anything, if the cumulative size of the summary would
+// exceed the 1 MiB limit enforced by GitHub. Summary truncation is not applied.
+// If GITHUB_STEP_SUMMARY is not set, it behaves as described in AddStepSummary
+// and the size limit is not enforced.
+func (c *Action) AddStepSummaryErr(markdown string) error {
+	if c.getenv("GITHUB_STEP_SUMMARY") == "" {
+		return c.stepSummaryUnset(markdown)
+	}
+
+	n := int64(len(markdown) + len(c.lineEnding()))
+	if size := c.stepSummarySize(); size+n > stepSummaryLimit {
+		return fmt.Errorf("step summary would be %d bytes, which exceeds the limit of %d bytes", size+n, stepSummaryLimit)
+	}
+
+	if err := c.issueFileCommand(&Command{
+		Name:    stepSummaryCmd,
+		Message: markdown,
+	}); err != nil {
+		return err
+	}
+
+	if c.summarySize != nil {
+		c.summarySize.Add(n)
+	}
+	return nil
+}
+
+// OverwriteStepSummary replaces the contents of the job summary with the given
+// markdown, instead of appending to it. This is equivalent to
+// core.summary.write({overwrite: true}) in the JavaScript toolkit. Summary
+// truncation is applied as in AddStepSummary. If GITHUB_STEP_SUMMARY is not set,
+// the markdown is handled as in AddStepSummary. It panics if the markdown
+// exceeds the 1 MiB limit or it cannot write to the file.
+func (c *Action) OverwriteStepSummary(markdown string) {
+	if c.getenv("GITHUB_STEP_SUMMARY") == "" {
+		if err := c.stepSummaryUnset(markdown); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	if c.summaryTruncation {
+		markdown = truncateMarkdown(markdown, stepSummaryLimit-len(c.lineEnding()))
+	}
+
+	if err := c.replaceStepSummary(markdown + c.lineEnding()); err != nil {
+		panic(err)
+	}
+}
+
+// stepSummaryUnset handles a write to the step summary when
+// GITHUB_STEP_SUMMARY is not set. The markdown is written to the fallback
+// writer, if one is configured. Otherwise it is discarded and a debug message
+// is printed once.
+func (c *Action) stepSummaryUnset(markdown string) error {
+	if c.summaryFallback != nil {
+		if _, err := fmt.Fprint(c.summaryFallback, markdown+c.lineEnding()); err != nil {
+			return fmt.Errorf("failed to write step summary to fallback: %w", err)
+		}
+		return nil
+	}
+
+	var err error
+	notice := func() {
+		err = c.IssueCommandErr(&Command{
+			Name:    debugCmd,
+			Message: "GITHUB_STEP_SUMMARY is not set, step summaries will not be written",
+		})
+	}
+	if c.summaryUnsetOnce != nil {
+		c.summaryUnsetOnce.Do(notice)
+	} else {
+		notice()
+	}
+	return err
+}
+
+// RemoveStepSummary truncates the job summary file to empty, discarding any
+// content previously written in the step. This is equivalent to
+// core.summary.clear() in the JavaScript toolkit. If GITHUB_STEP_SUMMARY is not
+// set, there is no summary to clear and it does nothing.
+func (c *Action) RemoveStepSummary() error {
+	if c.getenv("GITHUB_STEP_SUMMARY") == "" {
+		return nil
+	}
+	return c.replaceStepSummary("")
+}
+
+// replaceStepSummary truncates the job summary file, writes s, and resets the
+// size counter to the resulting size of the file.
+func (c *Action) replaceStepSummary(s string) (retErr error) {
+	if len(s) > stepSummaryLimit {
+		return fmt.Errorf("step summary would be %d bytes, which exceeds the limit of %d bytes", len(s), stepSummaryLimit)
+	}
+
+	pth := c.getenv("GITHUB_STEP_SUMMARY")
+	if pth == "" {
+		return fmt.Errorf("missing GITHUB_STEP_SUMMARY in environment")
+	}
+
+	defer c.lockFiles()()
+
+	f, err := os.OpenFile(pth, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open step summary: %w", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = fmt.Errorf("failed to close step summary: %w", err)
+		}
+	}()
+
+	if _, err := f.WriteString(s); err != nil {
+		return fmt.Errorf("failed to write step summary: %w", err)
+	}
+
+	if c.summarySize != nil {
+		c.summarySize.Store(int64(len(s)))
+	}
+	return nil
+}
+
+// stepSummarySize returns the current size of the step summary: the larger of
+// the bytes written by this action and the size of the summary file.
+func (c *Action) stepSummarySize() int64 {
+	var size int64
+	if c.summarySize != nil {
+		size = c.summarySize.Load()
+	}
+	if fi, err := os.Stat(c.getenv("GITHUB_STEP_SUMMARY")); err == nil && fi.Size() > size {
+		size = fi.Size()
+	}
+	return size
+}
+
+// stepSummaryLimit is the maximum size of a step summary in bytes.
+const stepSummaryLimit = 1024 * 1024
+
+// truncatedNote is appended to markdown that was truncated.
+const truncatedNote = "\n\n_(truncated)_"
+
+// truncateMarkdown truncates the markdown to at most max bytes. If truncation
+// is required, the markdown is cut at the end of the last complete block (the
+// last blank line or heading) that fits, trailing headings without content are
+// removed, and a note is appended.
+func truncateMarkdown(md string, max int) string {
+	if len(md) <= max {
+		return md
+	}
+
+	budget := max - len(truncatedNote)
+	if budget <= 0 {
+		return ""
+	}
+
+	head := md[:budget]
+	cut := strings.LastIndex(head, "\n\n")
+	if i := strings.LastIndex(head, "\n#"); i > cut {
+		cut = i
+	}
+	if cut < 0 {
+		cut = 0
+	}
+	out := strings.TrimRight(md[:cut], "\n")
+
+	// Drop any trailing headings whose content was truncated.
+	for out != "" {
+		i := strings.LastIndex(out, "\n")
+		if !strings.HasPrefix(out[i+1:], "#") {
+			break
+		}
+		out = strings.TrimRight(out[:i+1], "\n")
+	}
+	return out + truncatedNote
+}
+
+// AddStepSummaryTemplate adds a summary template by parsing the given Go
+// template using html/template with the given input data. See AddStepSummary
+// for caveats.
+//
+// Because html/template is used, characters such as "<", ">", and "&" in the
+// data are HTML-escaped. To render data as raw markdown or HTML, use
+// AddStepSummaryTextTemplate instead.
+//
+// This primarily exists as a convenience function that renders a template.
+func (c *Action) AddStepSummaryTemplate(tmpl string, data any) error {
+	t, err := template.New("").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	c.AddStepSummary(b.String())
+	return nil
+}
+
+// AddStepSummaryTextTemplate adds a summary template by parsing the given Go
+// template using text/template with the given input data. Unlike
+// AddStepSummaryTemplate, the data is not HTML-escaped, so it is rendered as raw
+// markdown, including code blocks and inline HTML. Only use this with trusted
+// data. See AddStepSummary for caveats.
+func (c *Action) AddStepSummaryTextTemplate(tmpl string, data any) error {
+	t, err := texttemplate.New("").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	c.AddStepSummary(b.String())
+	return nil
+}
+
+// AddStepSummaryImage appends an image to the job summary. It uses an HTML
+// <img> tag instead of markdown image syntax so that the dimensions can be set.
+// Attributes are HTML-escaped, and width and height are omitted when zero. See
+// AddStepSummary for caveats.
+func (c *Action) AddStepSummaryImage(src, alt string, width, height int) {
+	c.AddStepSummary(summaryImageTag(src, alt, width, height))
+}
+
+// summaryImageTag builds the HTML <img> tag for AddStepSummaryImage.
+func summaryImageTag(src, alt string, width, height int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<img src="%s" alt="%s"`, html.EscapeString(src), html.EscapeString(alt))
+	if width > 0 {
+		fmt.Fprintf(&b, ` width="%d"`, width)
+	}
+	if height > 0 {
+		fmt.Fprintf(&b, ` height="%d"`, height)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// WriteStepSummaryTo copies the current contents of the job summary to the
+// given writer. This is useful for archiving or uploading the summary. It does
+// not modify the job summary. If GITHUB_STEP_SUMMARY is not set, there is no
+// summary to copy and nothing is written.
+func (c *Action) WriteStepSummaryTo(w io.Writer) (retErr error) {
+	pth := c.getenv("GITHUB_STEP_SUMMARY")
+	if pth == "" {
+		return nil
+	}
+
+	f, err := os.Open(pth)
+	if err != nil {
+		return fmt.Errorf("failed to open step summary: %w", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = fmt.Errorf("failed to close step summary: %w", err)
+		}
+	}()
+
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("failed to copy step summary: %w", err)
+	}
+	return nil
+}
+
+// SetEnv sets an environment variable. It panics if the name is invalid or it
+// cannot write to the environment file. Use SetEnvErr to handle errors.
+//
+// https://docs.github.com/en/free-pro-team@latest/actions/reference/workflow-commands-for-github-actions#setting-an-environment-variable
+// https://github.blog/changelog/2020-10-01-github-actions-deprecating-set-env-and-add-path-commands/
+func (c *Action) SetEnv(k, v string) {
+	if err := c.SetEnvErr(k, v); err != nil {
+		panic(err)
+	}
+}
+
+// SetEnvErr sets an environment variable like SetEnv, but returns an error
+// instead of panicking. It returns an error if the name is empty or contains
+// "=", line breaks, or the delimiter prefix, or if it cannot write to the
+// environment file.
+func (c *Action) SetEnvErr(k, v string) error {
+	if err := c.issueMultilineFileCommand(envCmd, k, v); err != nil {
+		return fmt.Errorf("failed to set environment variable %q: %w", k, err)
+	}
+	return nil
+}
+
+// SetEnvs sets each of the given environment variables. Keys are validated
+// before anything is written: they must be non-empty and must not contain "=",
+// line breaks, or the "ghadelimiter_" prefix. If any key is invalid, no variables are set and an error
+// describing all invalid keys is returned. Variables are written in sorted
+// order by key. Values are written like TrySetOutput, so interior and trailing
+// newlines are preserved.
+func (c *Action) SetEnvs(m map[string]string) error {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var merr error
+	for _, k := range keys {
+		if err := validateFileCommandKey(k); err != nil {
+			merr = errors.Join(merr, err)
+		}
+	}
+	if merr != nil {
+		return merr
+	}
+
+	for _, k := range keys {
+		if err := c.issueMultilineFileCommand(envCmd, k, m[k]); err != nil {
+			merr = errors.Join(merr, fmt.Errorf("failed to set %q: %w", k, err))
+		}
+	}
+	return merr
+}
+
+// SetOutput sets an output parameter. It panics if the name is invalid or it
+// cannot write to the output file. Use SetOutputErr to handle errors.
+//
+// On 2022-10-11, GitHub deprecated "::set-output name=<k>::<v>" in favor of
+// [environment files].
+//
+// [environment files]: https://github.blog/changelog/2022-10-11-github-actions-deprecating-save-state-and-set-output-commands/
+func (c *Action) SetOutput(k, v string) {
+	if err := c.SetOutputErr(k, v); err != nil {
+		panic(err)
+	}
+}
+
+// SetOutputErr sets an output parameter like SetOutput, but returns an error
+// instead of panicking. It returns an error if the name is empty or contains
+// "=", line breaks, or the delimiter prefix, or if it cannot write to the
+// output file. Unlike TrySetOutput, the name is not normalized and the size of
+// the value is not checked.
+func (c *Action) SetOutputErr(k, v string) error {
+	if err := c.issueMultilineFileCommand(outputCmd, k, v); err != nil {
+		return fmt.Errorf("failed to set output %q: %w", k, err)
+	}
+	return nil
+}
+
+// DeclareOutputs declares outputs that the action must set before it exits,
+// such as those listed in action.yml. Use VerifyOutputs at the end of the
+// action to report declared outputs that were never set. Declarations are
+// shared with actions derived with WithFieldsMap and similar methods.
+func (c *Action) DeclareOutputs(names ...string) {
+	c.outputs.declare(names...)
+}
+
+// VerifyOutputs returns an error listing every output declared with
+// DeclareOutputs that was not set with SetOutput or a related method. Outputs
+// set to the empty string count as set. It returns nil if all declared outputs
+// were set.
+func (c *Action) VerifyOutputs() error {
+	if missing := c.outputs.missing(); len(missing) > 0 {
+		return fmt.Errorf("declared outputs were not set: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// FinalizeOutputs should be called after all outputs are set, such as before
+// the action exits. When debug logging is enabled on the runner, it prints a
+// debug message listing each output set by the action and its value, with
+// values registered with AddMask redacted. It then flushes the output stream if
+// it implements "Flush() error", and syncs the writer set with WithTee if it
+// implements "Sync() error", such as an *os.File. It returns an error if the
+// message cannot be written or a flush fails.
 //
-// [environment files]: https://github.blog/changelog/2022-10-11-github-actions-deprecating-save-state-and-set-output-commands/
-func (c *Action) SetOutput(k, v string) {
-	c.IssueFileCommand(&Command{
+// Output files are opened and closed on each write, so outputs are already on
+// disk when this is called.
+func (c *Action) FinalizeOutputs() error {
+	if c.IsDebug() {
+		keys, values := c.outputs.sorted()
+
+		msg := "No outputs set"
+		if len(keys) > 0 {
+			pairs := make([]string, 0, len(keys))
+			for _, k := range keys {
+				v := values[k]
+				if c.masks.contains(v) {
+					v = "***"
+				}
+				pairs = append(pairs, k+"="+v)
+			}
+			msg = fmt.Sprintf("Outputs set (%d): %s", len(keys), strings.Join(pairs, ", "))
+		}
+
+		if err := c.IssueCommandErr(&Command{
+			Name:    debugCmd,
+			Message: msg,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if f, ok := c.w.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return fmt.Errorf("failed to flush output: %w", err)
+		}
+	}
+	if f, ok := c.tee.(interface{ Sync() error }); ok {
+		if err := f.Sync(); err != nil {
+			return fmt.Errorf("failed to sync tee: %w", err)
+		}
+	}
+	return nil
+}
+
+// SetOutputDerived sets an output parameter to a value derived from a secret,
+// such as a hash of a token. The secret is masked with AddMask before derive is
+// called, so it is redacted if derive logs it, and the output is set to the
+// result of derive. The result itself is not masked. It panics if it cannot
+// write to the output stream or the output file.
+func (c *Action) SetOutputDerived(name, secret string, derive func(string) string) {
+	c.AddMask(secret)
+	c.SetOutput(name, derive(secret))
+}
+
+// SetOutputs sets each of the given output parameters. Unlike calling SetOutput
+// for each pair, the output file is opened once and all pairs are written with
+// a single write, in sorted order by key, using the multiline delimiter syntax.
+// This avoids interleaving with concurrent writers. It panics if it cannot
+// write to the output file.
+func (c *Action) SetOutputs(kv map[string]string) {
+	if len(kv) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(kv))
+	for k := range kv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for i, k := range keys {
+		msg, err := c.multilineFileCommand(k, kv[k])
+		if err != nil {
+			panic(err)
+		}
+		if i > 0 {
+			b.WriteString(c.lineEnding())
+		}
+		b.WriteString(msg)
+	}
+
+	if err := c.issueFileCommand(&Command{
 		Name:    outputCmd,
-		Message: fmt.Sprintf(multilineFileCmd, k, v),
+		Message: b.String(),
+	}); err != nil {
+		panic(err)
+	}
+
+	for _, k := range keys {
+		c.outputs.set(k, kv[k])
+	}
+}
+
+// defaultMaxOutputSize is the default maximum size of a single output value
+// accepted by TrySetOutput.
+const defaultMaxOutputSize = 1024 * 1024
+
+// TrySetOutput sets an output parameter like SetOutput, but returns an error
+// instead of panicking. The name is validated and normalized with
+// NormalizeOutputName, and an error is returned if the value is larger than the
+// maximum output size (1 MiB by default, see WithMaxOutputSize) rather than
+// writing an output the runner would reject.
+//
+// The value is written verbatim between delimiter lines, so interior newlines
+// are preserved exactly. The line ending before the closing delimiter belongs
+// to the file format, not the value, so a value that ends in a newline keeps
+// it.
+func (c *Action) TrySetOutput(k, v string) error {
+	name, err := NormalizeOutputName(k)
+	if err != nil {
+		return err
+	}
+
+	max := c.maxOutputSize
+	if max <= 0 {
+		max = defaultMaxOutputSize
+	}
+	if len(v) > max {
+		return fmt.Errorf("output %q is %d bytes, which exceeds the maximum of %d bytes", name, len(v), max)
+	}
+
+	if err := c.issueMultilineFileCommand(outputCmd, name, v); err != nil {
+		return fmt.Errorf("failed to set output %q: %w", name, err)
+	}
+	return nil
+}
+
+// SetOutputJSONSafe sets an output parameter to the compact JSON encoding of v,
+// so it can be consumed with fromJSON in subsequent steps. The value survives
+// the multiline file command round-trip by construction: json.Marshal escapes
+// line breaks inside strings, so the encoding is always a single line and
+// cannot contain a delimiter line. It returns an error if v cannot be marshaled
+// or the output cannot be written. The output is written with TrySetOutput.
+func (c *Action) SetOutputJSONSafe(name string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal output %q as json: %w", name, err)
+	}
+	return c.TrySetOutput(name, string(b))
+}
+
+// resultOutputName is the name of the output set by SetResult.
+const resultOutputName = "result"
+
+// jobResult is the JSON representation of the output set by SetResult.
+type jobResult struct {
+	Status  string            `json:"status"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// SetResult sets a machine-readable "result" output containing the status and
+// details as JSON, such as {"status":"success","details":{"k":"v"}}. The status
+// must be one of "success", "failure", "skipped", or "neutral". It returns an
+// error if the status is invalid or the output cannot be written.
+func (c *Action) SetResult(status string, details map[string]string) error {
+	switch status {
+	case "success", "failure", "skipped", "neutral":
+	default:
+		return fmt.Errorf("invalid result status %q: must be one of "+
+			"\"success\", \"failure\", \"skipped\", or \"neutral\"", status)
+	}
+
+	b, err := json.Marshal(&jobResult{
+		Status:  status,
+		Details: details,
 	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	if err := c.issueMultilineFileCommand(outputCmd, resultOutputName, string(b)); err != nil {
+		return fmt.Errorf("failed to set result: %w", err)
+	}
+	return nil
+}
+
+// SetOutputIfChanged sets the output parameter and saves it as state only if
+// the value differs from the state previously saved under the same name (which
+// the runner exposes as "STATE_<name>"). It returns true if the output was
+// written.
+func (c *Action) SetOutputIfChanged(k, v string) (bool, error) {
+	if c.getenv("STATE_"+k) == v {
+		return false, nil
+	}
+
+	if err := c.issueMultilineFileCommand(outputCmd, k, v); err != nil {
+		return false, fmt.Errorf("failed to set output %q: %w", k, err)
+	}
+
+	if err := c.issueMultilineFileCommand(stateCmd, k, v); err != nil {
+		return true, fmt.Errorf("failed to save state %q: %w", k, err)
+	}
+	return true, nil
+}
+
+// SetOutputsWithSummary sets each of the given output parameters and appends a
+// two-column markdown table of the outputs to the job summary. Outputs are
+// written in sorted order by key. It panics if it cannot write to the output
+// files.
+func (c *Action) SetOutputsWithSummary(m map[string]string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	c.SetOutputs(m)
+
+	eol := c.lineEnding()
+	var b strings.Builder
+	b.WriteString("| Output | Value |" + eol)
+	b.WriteString("| --- | --- |" + eol)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "| %s | %s |"+eol, escapeMarkdownCell(k), escapeMarkdownCell(m[k]))
+	}
+
+	c.AddStepSummary(b.String())
+}
+
+// escapeMarkdownCell escapes the value for use in a markdown table cell.
+func escapeMarkdownCell(v string) string {
+	v = strings.ReplaceAll(v, "|", "\\|")
+	v = strings.ReplaceAll(v, "\r\n", "<br>")
+	v = strings.ReplaceAll(v, "\n", "<br>")
+	return v
+}
+
+// outputNameRe matches valid output names: a letter or underscore followed by
+// letters, digits, hyphens, or underscores.
+var outputNameRe = regexp.MustCompile(`^[a-z_][a-z0-9_-]*$`)
+
+// NormalizeOutputName validates the output name and returns it in a normalized
+// form that round-trips through "steps.<id>.outputs.<name>". Surrounding
+// whitespace is trimmed, inner spaces are replaced with underscores, and the
+// name is lowercased. It returns an error if the result contains characters
+// other than letters, digits, hyphens, and underscores, or does not start with
+// a letter or underscore.
+func NormalizeOutputName(name string) (string, error) {
+	n := strings.TrimSpace(name)
+	n = strings.ReplaceAll(n, " ", "_")
+	n = strings.ToLower(n)
+
+	if n == "" {
+		return "", fmt.Errorf("invalid output name %q: cannot be empty", name)
+	}
+	if !outputNameRe.MatchString(n) {
+		return "", fmt.Errorf("invalid output name %q: must start with a letter or underscore "+
+			"and contain only letters, digits, hyphens, and underscores", name)
+	}
+	return n, nil
 }
 
 // Debugf prints a debug-level message. It follows the standard fmt.Printf
@@ -319,6 +2098,56 @@ func (c *Action) Noticef(msg string, args ...any) {
 	})
 }
 
+// NoticeResources prints a notice-level message summarizing the given
+// resources, such as those created by an infrastructure-provisioning action.
+// The message is the title followed by one "- <name>: <value>" line per
+// resource, sorted by name. It panics if it cannot write to the output stream.
+func (c *Action) NoticeResources(title string, resources map[string]string) {
+	keys := make([]string, 0, len(resources))
+	for k := range resources {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(title)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\n- %s: %s", k, resources[k])
+	}
+
+	// ::notice <c.fields>::<title>%0A- <name>: <value>...
+	c.IssueCommand(&Command{
+		Name:       noticeCmd,
+		Message:    b.String(),
+		Properties: c.fields,
+	})
+}
+
+// NoticeResourcesWithSummary prints the same notice as NoticeResources and
+// appends a two-column markdown table of the resources, under the title as a
+// heading, to the job summary. Resources are written in sorted order by name.
+// It panics if it cannot write to the output stream or the summary file.
+func (c *Action) NoticeResourcesWithSummary(title string, resources map[string]string) {
+	c.NoticeResources(title, resources)
+
+	keys := make([]string, 0, len(resources))
+	for k := range resources {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	eol := c.lineEnding()
+	var b strings.Builder
+	b.WriteString("### " + title + eol + eol)
+	b.WriteString("| Resource | Value |" + eol)
+	b.WriteString("| --- | --- |" + eol)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "| %s | %s |"+eol, escapeMarkdownCell(k), escapeMarkdownCell(resources[k]))
+	}
+
+	c.AddStepSummary(b.String())
+}
+
 // Warningf prints a warning-level message. It follows the standard fmt.Printf
 // arguments, appending an OS-specific line break to the end of the message. It
 // panics if it cannot write to the output stream.
@@ -331,6 +2160,24 @@ func (c *Action) Warningf(msg string, args ...any) {
 	})
 }
 
+// WarningWithDocs prints a warning-level message that links to documentation,
+// such as remediation steps, in the form "<msg> (see: <docURL>)". Any fields set
+// on the action, such as "title" or "file", are included as annotation
+// properties. If docURL is empty, only the message is printed. It panics if it
+// cannot write to the output stream.
+func (c *Action) WarningWithDocs(msg, docURL string) {
+	if docURL != "" {
+		msg = fmt.Sprintf("%s (see: %s)", msg, docURL)
+	}
+
+	// ::warning <c.fields>::<msg> (see: <docURL>)
+	c.IssueCommand(&Command{
+		Name:       warningCmd,
+		Message:    msg,
+		Properties: c.fields,
+	})
+}
+
 // Errorf prints a error-level message. It follows the standard fmt.Printf
 // arguments, appending an OS-specific line break to the end of the message. It
 // panics if it cannot write to the output stream.
@@ -343,6 +2190,169 @@ func (c *Action) Errorf(msg string, args ...any) {
 	})
 }
 
+// Annotation describes where an annotation is displayed. Empty strings and
+// zero values are omitted from the command.
+//
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#setting-an-error-message
+type Annotation struct {
+	// Title is a custom title for the annotation.
+	Title string
+
+	// File is the path of the file, relative to the repository root.
+	File string
+
+	// Line and EndLine are the first and last lines of the annotated range.
+	Line    int
+	EndLine int
+
+	// Col and EndColumn are the first and last columns of the annotated range.
+	Col       int
+	EndColumn int
+}
+
+// AnnotateError emits an error-level annotation with the given properties. Any
+// fields set on the action are included, but the line and column positions are
+// always taken from the annotation. It panics if it cannot write to the output
+// stream.
+func (c *Action) AnnotateError(a Annotation, msg string) {
+	c.annotate(AnnotationLevelError, a, msg)
+}
+
+// AnnotateWarning emits a warning-level annotation with the given properties.
+// See AnnotateError for details.
+func (c *Action) AnnotateWarning(a Annotation, msg string) {
+	c.annotate(AnnotationLevelWarning, a, msg)
+}
+
+// AnnotateNotice emits a notice-level annotation with the given properties. See
+// AnnotateError for details.
+func (c *Action) AnnotateNotice(a Annotation, msg string) {
+	c.annotate(AnnotationLevelNotice, a, msg)
+}
+
+// AnnotatePRLine emits an annotation of the given level anchored to a single
+// line of a file. The file should be relative to the repository root so the
+// annotation renders inline on the pull request diff. Any fields set on the
+// action are included, but "file", "line", and "endLine" always take
+// precedence. If line is less than one, this is equivalent to AnnotateFile. It
+// panics if it cannot write to the output stream.
+func (c *Action) AnnotatePRLine(file string, line int, level AnnotationLevel, msg string) {
+	if line < 1 {
+		c.AnnotateFile(file, level, msg)
+		return
+	}
+
+	// ::<level> file=<file>,line=<line>,endLine=<line>::<msg>
+	c.annotate(level, Annotation{File: file, Line: line, EndLine: line}, msg)
+}
+
+// AnnotateFile emits an annotation of the given level scoped to a file, without
+// a line. The annotation is attached to the file as a whole. Any fields set on
+// the action are included, except for line and column positions. It panics if
+// it cannot write to the output stream.
+func (c *Action) AnnotateFile(file string, level AnnotationLevel, msg string) {
+	// ::<level> file=<file>::<msg>
+	c.annotate(level, Annotation{File: file}, msg)
+}
+
+// Finding is a single result from a checker or scanner, such as a linter
+// message, located in a file.
+type Finding struct {
+	// File is the path of the file, relative to the repository root.
+	File string
+
+	// Line is the line of the finding. Values less than one annotate the file
+	// as a whole.
+	Line int
+
+	// Message describes the finding.
+	Message string
+}
+
+// NoticeFindings emits one notice-level annotation per finding, in order, with
+// the file and line of the finding as annotation properties. See
+// AnnotatePRLine for how fields set on the action are handled. It panics if it
+// cannot write to the output stream.
+func (c *Action) NoticeFindings(findings []Finding) {
+	for _, f := range findings {
+		c.AnnotatePRLine(f.File, f.Line, AnnotationLevelNotice, f.Message)
+	}
+}
+
+// NoticeFindingsByFile is like NoticeFindings, but groups the findings by file.
+// The notices for each file are printed inside a collapsed group titled with
+// the file name. Files are sorted by name and the findings within each file
+// are sorted by line; findings on the same line keep their order. Findings
+// without a file are grouped under "(no file)". It panics if it cannot write
+// to the output stream.
+func (c *Action) NoticeFindingsByFile(findings []Finding) {
+	sorted := slices.Clone(findings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].File != sorted[j].File {
+			return sorted[i].File < sorted[j].File
+		}
+		return sorted[i].Line < sorted[j].Line
+	})
+
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j].File == sorted[i].File {
+			j++
+		}
+
+		title := sorted[i].File
+		if title == "" {
+			title = "(no file)"
+		}
+		c.GroupFunc(title, func() {
+			c.NoticeFindings(sorted[i:j])
+		})
+		i = j
+	}
+}
+
+// annotate issues an annotation command of the given level. The properties are
+// the action's fields, without any line or column positions, overridden by the
+// non-empty properties of the annotation.
+func (c *Action) annotate(level AnnotationLevel, a Annotation, msg string) {
+	props := make(CommandProperties, len(c.fields)+6)
+	for k, v := range c.fields {
+		switch k {
+		case "line", "endLine", "col", "endColumn":
+		default:
+			props[k] = v
+		}
+	}
+
+	if a.Title != "" {
+		props["title"] = a.Title
+	}
+	if a.File != "" {
+		props["file"] = a.File
+	}
+	for k, v := range map[string]int{
+		"line":      a.Line,
+		"endLine":   a.EndLine,
+		"col":       a.Col,
+		"endColumn": a.EndColumn,
+	} {
+		if v > 0 {
+			props[k] = strconv.Itoa(v)
+		}
+	}
+
+	c.IssueCommand(&Command{
+		Name:       string(level),
+		Message:    msg,
+		Properties: props,
+	})
+
+	if a.File == "" {
+		a.File = props["file"]
+	}
+	c.sarif.Add(level, a, msg)
+}
+
 // Fatalf prints a error-level message and exits. This is equivalent to Errorf
 // followed by os.Exit(1).
 func (c *Action) Fatalf(msg string, args ...any) {
@@ -354,11 +2364,43 @@ func (c *Action) Fatalf(msg string, args ...any) {
 // standard fmt.Printf arguments, appending an OS-specific line break to the end
 // of the message. It panics if it cannot write to the output stream.
 func (c *Action) Infof(msg string, args ...any) {
-	if _, err := fmt.Fprintf(c.w, msg+EOF, args...); err != nil {
+	s, err := c.guard(fmt.Sprintf(msg, args...))
+	if err != nil {
+		panic(fmt.Errorf("failed to write info command: %w", err))
+	}
+
+	if err := c.emit(s); err != nil {
 		panic(fmt.Errorf("failed to write info command: %w", err))
 	}
 }
 
+// Infoft renders the given Go template using text/template with the given
+// input data and prints the result to stdout without any level annotations,
+// like Infof. A single trailing newline in the result is replaced with an
+// OS-specific line break. Values registered with AddMask are redacted. It
+// returns an error if the template cannot be parsed or executed, or if the
+// result cannot be written.
+func (c *Action) Infoft(tmpl string, data any) error {
+	t, err := texttemplate.New("").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	s, err := c.guard(strings.TrimSuffix(b.String(), "\n"))
+	if err != nil {
+		return fmt.Errorf("failed to write info command: %w", err)
+	}
+	if err := c.emit(s); err != nil {
+		return fmt.Errorf("failed to write info command: %w", err)
+	}
+	return nil
+}
+
 // WithFieldsSlice includes the provided fields in log output. "f" must be a
 // slice of k=v pairs. The given slice will be sorted. It panics if any of the
 // string in the given slice does not construct a valid 'key=value' pair.
@@ -383,16 +2425,65 @@ func (c *Action) WithFieldsMap(m map[string]string) *Action {
 		w:          c.w,
 		fields:     m,
 		getenv:     c.getenv,
+		environ:    c.environ,
 		httpClient: c.httpClient,
+		masks:      c.masks,
+		outputs:    c.outputs,
+
+		summarySize: c.summarySize,
+		fileMu:      c.fileMu,
+
+		summaryFallback:  c.summaryFallback,
+		summaryUnsetOnce: c.summaryUnsetOnce,
+
+		leakDetection: c.leakDetection,
+		recorder:      c.recorder,
+		sarif:         c.sarif,
+
+		expressionWarnings: c.expressionWarnings,
+		eventOverlay:       c.eventOverlay,
+		secretMinLength:    c.secretMinLength,
+		secretMinEntropy:   c.secretMinEntropy,
+		jsonWriter:         c.jsonWriter,
+		summaryTruncation:  c.summaryTruncation,
+		startupContextLog:  c.startupContextLog,
+		maxOutputSize:      c.maxOutputSize,
+		eol:                c.eol,
+
+		deprecationWarnings: c.deprecationWarnings,
+		requireActionsEnv:   c.requireActionsEnv,
+
+		idTokenRetryAttempts: c.idTokenRetryAttempts,
+		idTokenRetryBase:     c.idTokenRetryBase,
+
+		clock:     c.clock,
+		tee:       c.tee,
+		errWriter: c.errWriter,
 	}
 }
 
+// WithExtraFieldsMap is like WithFieldsMap, but merges the provided fields into
+// a copy of the existing fields instead of replacing them. Keys in "m" take
+// precedence over existing keys. The receiver's fields are not modified.
+func (c *Action) WithExtraFieldsMap(m map[string]string) *Action {
+	merged := make(CommandProperties, len(c.fields)+len(m))
+	for k, v := range c.fields {
+		merged[k] = v
+	}
+	for k, v := range m {
+		merged[k] = v
+	}
+	return c.WithFieldsMap(merged)
+}
+
 // idTokenResponse is the response from minting an ID token.
 type idTokenResponse struct {
 	Value string `json:"value,omitempty"`
 }
 
-// GetIDToken returns the GitHub OIDC token from the GitHub Actions runtime.
+// GetIDToken returns the GitHub OIDC token from the GitHub Actions runtime. If
+// WithIDTokenRetry is set, transient failures are retried with exponential
+// backoff until the attempts are exhausted or ctx is done.
 func (c *Action) GetIDToken(ctx context.Context, audience string) (string, error) {
 	requestURL := c.getenv("ACTIONS_ID_TOKEN_REQUEST_URL")
 	if requestURL == "" {
@@ -404,45 +2495,243 @@ func (c *Action) GetIDToken(ctx context.Context, audience string) (string, error
 		return "", fmt.Errorf("missing ACTIONS_ID_TOKEN_REQUEST_TOKEN in environment")
 	}
 
-	u, err := url.Parse(requestURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse request URL: %w", err)
-	}
-	if audience != "" {
-		q := u.Query()
-		q.Set("audience", audience)
-		u.RawQuery = q.Encode()
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse request URL: %w", err)
+	}
+	if audience != "" {
+		q := u.Query()
+		q.Set("audience", audience)
+		u.RawQuery = q.Encode()
+	}
+
+	attempts := c.idTokenRetryAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	backoff := c.idTokenRetryBase
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	client := c.idTokenClient()
+	for attempt := 1; ; attempt++ {
+		token, retryable, err := c.mintIDToken(ctx, client, u.String(), requestToken)
+		if err == nil || !retryable || attempt >= attempts {
+			return token, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", fmt.Errorf("failed to mint OIDC token: %w", ctx.Err())
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// GetIDTokenClaims mints an OIDC token like GetIDToken and returns the claims
+// from its payload, such as "sub", "aud", and "repository".
+//
+// The token is decoded but NOT validated: its signature, issuer, audience, and
+// expiration are not checked. The claims are suitable for logging or
+// inspection, but must not be used to make authorization decisions. To verify
+// the token, use a JWT library with GitHub's published keys.
+func (c *Action) GetIDTokenClaims(ctx context.Context, audience string) (map[string]any, error) {
+	token, err := c.GetIDToken(ctx, audience)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("failed to parse OIDC token: expected 3 segments, got %d", len(parts))
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC token payload: %w", err)
+	}
+
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OIDC token claims: %w", err)
+	}
+	return claims, nil
+}
+
+// idTokenClient returns the HTTP client used to mint OIDC tokens. If retries
+// are configured with WithIDTokenRetry and the client's transport is a
+// RetryTransport, it returns a copy of the client that uses the underlying
+// transport instead, so that GetIDToken alone controls the number of attempts
+// and the backoff.
+func (c *Action) idTokenClient() *http.Client {
+	if c.idTokenRetryAttempts <= 0 || c.httpClient == nil {
+		return c.httpClient
+	}
+
+	rt, ok := c.httpClient.Transport.(*RetryTransport)
+	if !ok {
+		return c.httpClient
+	}
+
+	client := *c.httpClient
+	client.Transport = rt.Base
+	return &client
+}
+
+// mintIDToken makes a single request to mint an OIDC token. It returns true if
+// the request failed with a transient error that may succeed if retried: a
+// transport error or a non-2xx response other than 401 or 403.
+func (c *Action) mintIDToken(ctx context.Context, client *http.Client, requestURL, requestToken string) (string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", ctx.Err() == nil, fmt.Errorf("failed to make HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// This has moved to the io package in Go 1.16, but we still support up to Go
+	// 1.13 for now.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1000))
+	if err != nil {
+		return "", ctx.Err() == nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	body = bytes.TrimSpace(body)
+
+	if resp.StatusCode != 200 {
+		retryable := resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden
+		return "", retryable, fmt.Errorf("non-successful response from minting OIDC token: %s", body)
+	}
+
+	var tokenResp idTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", false, fmt.Errorf("failed to process response as JSON: %w", err)
+	}
+	return tokenResp.Value, false, nil
+}
+
+// HashFiles returns a SHA-256 hex digest of the files in the workspace that
+// match any of the given glob patterns, mirroring the hashFiles() workflow
+// expression. Patterns are relative to GITHUB_WORKSPACE (or the current working
+// directory if unset), use "/" as the separator, and support "*", "?", and "**"
+// to match across directories. Matching files are hashed in sorted order by
+// relative path. It returns an error if no files match.
+//
+// https://docs.github.com/en/actions/learn-github-actions/expressions#hashfiles
+func (c *Action) HashFiles(globs ...string) (string, error) {
+	root := c.getenv("GITHUB_WORKSPACE")
+	if root == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get working directory: %w", err)
+		}
+		root = wd
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(globs))
+	for _, g := range globs {
+		re, err := globToRegexp(g)
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern %q: %w", g, err)
+		}
+		patterns = append(patterns, re)
+	}
+
+	var files []string
+	if err := filepath.WalkDir(root, func(pth string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, pth)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		for _, re := range patterns {
+			if re.MatchString(rel) {
+				files = append(files, rel)
+				break
+			}
+		}
+		return nil
+	}); err != nil {
+		return "", fmt.Errorf("failed to walk workspace: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	if len(files) == 0 {
+		return "", fmt.Errorf("no files matched %q", globs)
 	}
-	req.Header.Set("Authorization", "Bearer "+requestToken)
+	sort.Strings(files)
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to make HTTP request: %w", err)
+	result := sha256.New()
+	for _, rel := range files {
+		sum, err := hashFile(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			return "", err
+		}
+		result.Write(sum)
 	}
-	defer resp.Body.Close()
+	return hex.EncodeToString(result.Sum(nil)), nil
+}
 
-	// This has moved to the io package in Go 1.16, but we still support up to Go
-	// 1.13 for now.
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1000))
+// hashFile returns the SHA-256 digest of the file at the given path.
+func hashFile(pth string) ([]byte, error) {
+	f, err := os.Open(pth)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
-	body = bytes.TrimSpace(body)
+	defer f.Close()
 
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("non-successful response from minting OIDC token: %s", body)
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("failed to hash %s: %w", pth, err)
 	}
+	return h.Sum(nil), nil
+}
 
-	var tokenResp idTokenResponse
-	if err := json.Unmarshal(body, &tokenResp); err != nil {
-		return "", fmt.Errorf("failed to process response as JSON: %w", err)
+// globToRegexp converts a glob pattern to a regular expression. "**" matches
+// any number of path segments, "*" matches within a segment, and "?" matches a
+// single non-separator character.
+func globToRegexp(g string) (*regexp.Regexp, error) {
+	g = strings.TrimPrefix(filepath.ToSlash(g), "./")
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(g); i++ {
+		switch ch := g[i]; ch {
+		case '*':
+			if i+1 < len(g) && g[i+1] == '*' {
+				i++
+				if i+1 < len(g) && g[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
 	}
-	return tokenResp.Value, nil
+	b.WriteString("$")
+	return regexp.Compile(b.String())
 }
 
 // Getenv retrieves the value of the environment variable named by the key.
@@ -451,10 +2740,49 @@ func (c *Action) Getenv(key string) string {
 	return c.getenv(key)
 }
 
+// GetenvBool retrieves the value of the environment variable named by the key
+// and interprets it as a boolean. The values "1", "true", "yes", and "on" are
+// true (case-insensitive, ignoring surrounding whitespace). All other values,
+// including the empty string, are false.
+func (c *Action) GetenvBool(key string) bool {
+	switch strings.ToLower(strings.TrimSpace(c.getenv(key))) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// RunnerOS is the operating system of the runner executing the job.
+type RunnerOS string
+
+const (
+	RunnerOSUnknown RunnerOS = ""
+	RunnerOSLinux   RunnerOS = "Linux"
+	RunnerOSWindows RunnerOS = "Windows"
+	RunnerOSMacOS   RunnerOS = "macOS"
+)
+
+// RunnerOS returns the operating system of the runner from RUNNER_OS. It
+// returns RunnerOSUnknown if the value is unset or not recognized.
+func (c *Action) RunnerOS() RunnerOS {
+	switch v := RunnerOS(c.getenv("RUNNER_OS")); v {
+	case RunnerOSLinux, RunnerOSWindows, RunnerOSMacOS:
+		return v
+	default:
+		return RunnerOSUnknown
+	}
+}
+
 // GetenvFunc is an abstraction to make tests feasible for commands that
 // interact with environment variables.
 type GetenvFunc func(key string) string
 
+// EnvironFunc is an abstraction to make tests feasible for commands that
+// interact with the full set of environment variables. It returns "key=value"
+// pairs like os.Environ.
+type EnvironFunc func() []string
+
 // GitHubContext of current workflow.
 //
 // See: https://docs.github.com/en/actions/learn-github-actions/environment-variables
@@ -497,6 +2825,7 @@ type GitHubContext struct {
 	SHA           string
 	StepSummary   string
 	Workflow      string
+	WorkflowRef   string
 	Workspace     string
 
 	// Event is populated by parsing the file at EventPath, if it exists.
@@ -537,6 +2866,581 @@ func (c *GitHubContext) Repo() (string, string) {
 	return ownerName, repoName
 }
 
+// JobLogURL returns a best-effort URL to the logs for the current job. The
+// runner only exposes the job's name (GITHUB_JOB) and not its numeric ID, so
+// the URL cannot deep-link to a specific job. Instead, it points to the jobs
+// view of the workflow run, from which the job can be selected. It returns the
+// empty string if the repository or run ID are unknown.
+func (c *GitHubContext) JobLogURL() string {
+	return c.runURL()
+}
+
+// RunAttemptURL returns the URL to the current attempt of the workflow run,
+// honoring the server URL for GitHub Enterprise Server. If the run attempt is
+// unknown, it returns the URL to the workflow run. It returns the empty string
+// if the repository or run ID are unknown.
+func (c *GitHubContext) RunAttemptURL() string {
+	u := c.runURL()
+	if u == "" || c.RunAttempt < 1 {
+		return u
+	}
+	return fmt.Sprintf("%s/attempts/%d", u, c.RunAttempt)
+}
+
+// runURL returns the URL to the workflow run, or the empty string if the
+// repository or run ID are unknown.
+func (c *GitHubContext) runURL() string {
+	if c == nil || c.Repository == "" || c.RunID == 0 {
+		return ""
+	}
+
+	serverURL := strings.TrimSuffix(c.ServerURL, "/")
+	return fmt.Sprintf("%s/%s/actions/runs/%d", serverURL, c.Repository, c.RunID)
+}
+
+const (
+	defaultAPIURL    = "https://api.github.com/"
+	defaultUploadURL = "https://uploads.github.com/"
+)
+
+// APIEndpoints returns the REST API base URL and the upload URL for the
+// instance running the workflow. Both URLs end in a trailing slash. For
+// github.com, or when the API URL is unknown, it returns the public endpoints.
+// For GitHub Enterprise Server, the upload URL is derived from the server URL,
+// since the runner does not expose it directly.
+func (c *GitHubContext) APIEndpoints() (baseURL, uploadURL string) {
+	if c == nil {
+		return defaultAPIURL, defaultUploadURL
+	}
+
+	apiURL := strings.TrimSuffix(c.APIURL, "/")
+	if apiURL == "" || apiURL+"/" == defaultAPIURL {
+		return defaultAPIURL, defaultUploadURL
+	}
+
+	serverURL := strings.TrimSuffix(c.ServerURL, "/")
+	if serverURL == "" {
+		serverURL = strings.TrimSuffix(apiURL, "/api/v3")
+	}
+	return apiURL + "/", serverURL + "/api/uploads/"
+}
+
+// defaultAbbreviatedSHALength is the default length of an abbreviated SHA,
+// matching the default of "git rev-parse --short".
+const defaultAbbreviatedSHALength = 7
+
+// AbbreviatedSHA returns the first n characters of the commit SHA. If n is less
+// than or equal to zero, a default of 7 is used. If n is larger than the SHA,
+// the full SHA is returned. Unlike git, this does not guarantee the result is
+// unique within the repository.
+func (c *GitHubContext) AbbreviatedSHA(n int) string {
+	if c == nil {
+		return ""
+	}
+
+	if n <= 0 {
+		n = defaultAbbreviatedSHALength
+	}
+	if n > len(c.SHA) {
+		n = len(c.SHA)
+	}
+	return c.SHA[:n]
+}
+
+// EventAction returns the "action" field of the event payload, such as "opened"
+// or "synchronize" for pull_request events. It returns the empty string if the
+// payload does not have an action.
+func (c *GitHubContext) EventAction() string {
+	if c == nil || c.Event == nil {
+		return ""
+	}
+
+	action, _ := c.Event["action"].(string)
+	return action
+}
+
+// MergeGroup returns the base and head commit SHAs from the "merge_group"
+// object of the event payload, for workflows triggered by a merge queue. It
+// returns false if the workflow was not triggered by a merge_group event or the
+// payload does not contain a merge group.
+func (c *GitHubContext) MergeGroup() (baseSHA, headSHA string, ok bool) {
+	if c == nil || c.EventName != "merge_group" || c.Event == nil {
+		return "", "", false
+	}
+
+	group, ok := c.Event["merge_group"].(map[string]any)
+	if !ok {
+		return "", "", false
+	}
+
+	baseSHA, _ = group["base_sha"].(string)
+	headSHA, _ = group["head_sha"].(string)
+	return baseSHA, headSHA, true
+}
+
+// WorkflowRun returns the ID and conclusion of the upstream run from the
+// "workflow_run" object of the event payload, for workflows chained with the
+// workflow_run trigger. The conclusion is empty while the upstream run is in
+// progress. It returns false if the workflow was not triggered by a
+// workflow_run event or the payload does not contain a run ID.
+func (c *GitHubContext) WorkflowRun() (id int64, conclusion string, ok bool) {
+	if c == nil || c.EventName != "workflow_run" || c.Event == nil {
+		return 0, "", false
+	}
+
+	run, ok := c.Event["workflow_run"].(map[string]any)
+	if !ok {
+		return 0, "", false
+	}
+
+	id, ok = toInt64(run["id"])
+	if !ok {
+		return 0, "", false
+	}
+	conclusion, _ = run["conclusion"].(string)
+	return id, conclusion, true
+}
+
+// HeadCommit returns the ID and message of the most recent commit from the
+// "head_commit" object of a push event payload. It returns false if the
+// workflow was not triggered by a push event or the payload has no head
+// commit, such as when a branch is deleted.
+func (c *GitHubContext) HeadCommit() (id, message string, ok bool) {
+	if c == nil || c.EventName != "push" || c.Event == nil {
+		return "", "", false
+	}
+
+	commit, ok := c.Event["head_commit"].(map[string]any)
+	if !ok {
+		return "", "", false
+	}
+
+	id, _ = commit["id"].(string)
+	message, _ = commit["message"].(string)
+	return id, message, true
+}
+
+// EventLabel returns the name and color of the label from the "label" object
+// of a label event payload, which is sent when a label is created, edited, or
+// deleted. The color is a hex code without the leading "#". It returns false if
+// the workflow was not triggered by a label event or the payload has no label.
+func (c *GitHubContext) EventLabel() (name, color string, ok bool) {
+	if c == nil || c.EventName != "label" || c.Event == nil {
+		return "", "", false
+	}
+
+	label, ok := c.Event["label"].(map[string]any)
+	if !ok {
+		return "", "", false
+	}
+
+	name, _ = label["name"].(string)
+	color, _ = label["color"].(string)
+	return name, color, true
+}
+
+// Pusher returns the name and email of the user who pushed the commits from
+// the "pusher" object of a push event payload. It returns false if the
+// workflow was not triggered by a push event or the payload has no pusher.
+func (c *GitHubContext) Pusher() (name, email string, ok bool) {
+	if c == nil || c.EventName != "push" || c.Event == nil {
+		return "", "", false
+	}
+
+	pusher, ok := c.Event["pusher"].(map[string]any)
+	if !ok {
+		return "", "", false
+	}
+
+	name, _ = pusher["name"].(string)
+	email, _ = pusher["email"].(string)
+	return name, email, true
+}
+
+// EditChanges returns the "changes" object of the event payload, which
+// describes the previous values of the fields modified by an "edited" action on
+// issue, pull_request, and similar events. It returns false if the payload does
+// not contain changes.
+func (c *GitHubContext) EditChanges() (map[string]any, bool) {
+	if c == nil || c.Event == nil {
+		return nil, false
+	}
+
+	changes, ok := c.Event["changes"].(map[string]any)
+	return changes, ok
+}
+
+// ScheduleCron returns the cron expression that triggered the workflow from the
+// event payload. It returns false if the workflow was not triggered by a
+// schedule event or the payload does not contain the expression.
+func (c *GitHubContext) ScheduleCron() (string, bool) {
+	if c == nil || c.EventName != "schedule" || c.Event == nil {
+		return "", false
+	}
+
+	cron, ok := c.Event["schedule"].(string)
+	if !ok || cron == "" {
+		return "", false
+	}
+	return cron, true
+}
+
+// Sender returns the login and ID of the user that triggered the event from the
+// "sender" object of the event payload. This may differ from Actor. It returns
+// false if the payload does not contain a sender.
+func (c *GitHubContext) Sender() (login string, id int64, ok bool) {
+	if c == nil || c.Event == nil {
+		return "", 0, false
+	}
+
+	sender, ok := c.Event["sender"].(map[string]any)
+	if !ok {
+		return "", 0, false
+	}
+
+	login, _ = sender["login"].(string)
+	id, _ = toInt64(sender["id"])
+	return login, id, true
+}
+
+// IssueNumber returns the number of the issue from the "issue" object of the
+// event payload, such as for issues and issue_comment events. Comments on pull
+// requests are delivered as issue_comment events, so this is also the pull
+// request number in that case. It returns false if the payload does not contain
+// an issue number.
+func (c *GitHubContext) IssueNumber() (int, bool) {
+	if c == nil || c.Event == nil {
+		return 0, false
+	}
+
+	issue, ok := c.Event["issue"].(map[string]any)
+	if !ok {
+		return 0, false
+	}
+
+	n, ok := toInt64(issue["number"])
+	return int(n), ok
+}
+
+// CommentID returns the ID of the comment from the "comment" object of the
+// event payload, such as for issue_comment and pull_request_review_comment
+// events. It returns false if the payload does not contain a comment ID.
+func (c *GitHubContext) CommentID() (int64, bool) {
+	if c == nil || c.Event == nil {
+		return 0, false
+	}
+
+	comment, ok := c.Event["comment"].(map[string]any)
+	if !ok {
+		return 0, false
+	}
+
+	return toInt64(comment["id"])
+}
+
+// toInt64 converts a numeric value decoded from JSON to an int64. JSON numbers
+// decode as float64 into an "any".
+func toInt64(v any) (int64, bool) {
+	switch t := v.(type) {
+	case float64:
+		return int64(t), true
+	case int64:
+		return t, true
+	case int:
+		return int64(t), true
+	case json.Number:
+		i, err := t.Int64()
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// IsManualDispatch returns true if the workflow was triggered manually by a
+// workflow_dispatch event.
+func (c *GitHubContext) IsManualDispatch() bool {
+	return c != nil && c.EventName == "workflow_dispatch"
+}
+
+// DispatchInputs returns the inputs provided when the workflow was manually
+// dispatched. It returns nil if the workflow was not triggered by a
+// workflow_dispatch event or no inputs were provided.
+func (c *GitHubContext) DispatchInputs() map[string]any {
+	if !c.IsManualDispatch() || c.Event == nil {
+		return nil
+	}
+
+	inputs, _ := c.Event["inputs"].(map[string]any)
+	return inputs
+}
+
+// PushCommit is a commit from the "commits" array of a push event payload.
+type PushCommit struct {
+	ID      string           `json:"id"`
+	Message string           `json:"message"`
+	Author  PushCommitAuthor `json:"author"`
+}
+
+// PushCommitAuthor is the git author of a PushCommit.
+type PushCommitAuthor struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
+
+// PushEvent is the subset of the push event payload that is commonly used by
+// actions.
+//
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#push
+type PushEvent struct {
+	Ref     string           `json:"ref"`
+	BaseRef string           `json:"base_ref"`
+	Before  string           `json:"before"`
+	After   string           `json:"after"`
+	Created bool             `json:"created"`
+	Deleted bool             `json:"deleted"`
+	Forced  bool             `json:"forced"`
+	Compare string           `json:"compare"`
+	Commits []PushCommit     `json:"commits"`
+	Pusher  PushCommitAuthor `json:"pusher"`
+
+	// HeadCommit is nil if the push deleted the ref.
+	HeadCommit *PushCommit `json:"head_commit"`
+}
+
+// PushEvent decodes the event payload into a PushEvent. It returns an error if
+// the workflow was not triggered by a push event.
+func (c *GitHubContext) PushEvent() (*PushEvent, error) {
+	if c == nil || c.EventName != "push" {
+		return nil, fmt.Errorf("event is not a push event")
+	}
+
+	var e PushEvent
+	if err := c.UnmarshalEvent(&e); err != nil {
+		return nil, fmt.Errorf("failed to decode push event: %w", err)
+	}
+	return &e, nil
+}
+
+// PullRequestEvent is the subset of the pull_request event payload that is
+// commonly used by actions.
+//
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#pull_request
+type PullRequestEvent struct {
+	Action      string      `json:"action"`
+	Number      int         `json:"number"`
+	PullRequest PullRequest `json:"pull_request"`
+}
+
+// PullRequest is the pull request object of a PullRequestEvent.
+type PullRequest struct {
+	Title  string            `json:"title"`
+	Body   string            `json:"body"`
+	Head   PullRequestBranch `json:"head"`
+	Base   PullRequestBranch `json:"base"`
+	Merged bool              `json:"merged"`
+	Draft  bool              `json:"draft"`
+}
+
+// PullRequestBranch is the head or base branch of a PullRequest.
+type PullRequestBranch struct {
+	Label string `json:"label"`
+	Ref   string `json:"ref"`
+	SHA   string `json:"sha"`
+}
+
+// PullRequestEvent decodes the event payload into a PullRequestEvent. It
+// returns an error if the workflow was not triggered by a pull_request event.
+func (c *GitHubContext) PullRequestEvent() (*PullRequestEvent, error) {
+	if c == nil || c.EventName != "pull_request" {
+		return nil, fmt.Errorf("event is not a pull_request event")
+	}
+
+	var e PullRequestEvent
+	if err := c.UnmarshalEvent(&e); err != nil {
+		return nil, fmt.Errorf("failed to decode pull_request event: %w", err)
+	}
+	return &e, nil
+}
+
+// UnmarshalEvent decodes the event payload into v, which should be a pointer to
+// a struct describing the subset of the webhook payload the caller needs. It
+// returns an error if there is no event payload, which happens when the event
+// file was not present.
+func (c *GitHubContext) UnmarshalEvent(v any) error {
+	if c == nil || c.Event == nil {
+		return fmt.Errorf("no event payload: the event file at GITHUB_EVENT_PATH was not present")
+	}
+
+	b, err := json.Marshal(c.Event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("failed to unmarshal event payload: %w", err)
+	}
+	return nil
+}
+
+// PushCommits returns the commits from the event payload. It returns false if
+// the workflow was not triggered by a push event or the payload does not
+// contain a list of commits.
+func (c *GitHubContext) PushCommits() ([]PushCommit, bool) {
+	if c == nil || c.EventName != "push" || c.Event == nil {
+		return nil, false
+	}
+
+	raw, ok := c.Event["commits"].([]any)
+	if !ok {
+		return nil, false
+	}
+
+	commits := make([]PushCommit, 0, len(raw))
+	for _, v := range raw {
+		m, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		var commit PushCommit
+		commit.ID, _ = m["id"].(string)
+		commit.Message, _ = m["message"].(string)
+		if author, ok := m["author"].(map[string]any); ok {
+			commit.Author.Name, _ = author["name"].(string)
+			commit.Author.Email, _ = author["email"].(string)
+			commit.Author.Username, _ = author["username"].(string)
+		}
+		commits = append(commits, commit)
+	}
+	return commits, true
+}
+
+// TokenPermissionsHint returns advisory guidance about the permissions of the
+// GITHUB_TOKEN for the triggering event. The actual permissions are not exposed
+// to the runner, so this is based only on the event name and the documented
+// defaults. It returns the empty string if there is no specific guidance.
+//
+// https://docs.github.com/en/actions/security-guides/automatic-token-authentication#permissions-for-the-github_token
+func (c *GitHubContext) TokenPermissionsHint() string {
+	if c == nil {
+		return ""
+	}
+
+	switch c.EventName {
+	case "pull_request_target":
+		return "pull_request_target runs in the context of the base repository with a " +
+			"read/write GITHUB_TOKEN and access to secrets; do not check out or run " +
+			"untrusted code from the pull request"
+	case "workflow_run":
+		return "workflow_run runs with a read/write GITHUB_TOKEN and access to secrets, " +
+			"even when triggered by a pull request from a fork; treat artifacts from the " +
+			"triggering workflow as untrusted"
+	case "pull_request", "pull_request_review", "pull_request_review_comment":
+		return "pull requests from forks receive a read-only GITHUB_TOKEN and no " +
+			"access to secrets"
+	case "issue_comment":
+		return "issue_comment runs with the default GITHUB_TOKEN permissions of the " +
+			"base repository; validate the commenter before acting on the comment"
+	default:
+		return ""
+	}
+}
+
+// WorkflowPath returns the path of the workflow file relative to the
+// repository root, such as ".github/workflows/ci.yml", by parsing WorkflowRef.
+// It returns the empty string if WorkflowRef is empty or malformed.
+func (c *GitHubContext) WorkflowPath() string {
+	if c == nil || c.WorkflowRef == "" {
+		return ""
+	}
+
+	// owner/repo/.github/workflows/ci.yml@refs/heads/main
+	ref := c.WorkflowRef
+	if i := strings.LastIndex(ref, "@"); i >= 0 {
+		ref = ref[:i]
+	}
+
+	parts := strings.SplitN(ref, "/", 3)
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[2]
+}
+
+// Release returns the tag and name of the release from the event payload. It
+// returns false if the workflow was not triggered by a release event or the
+// payload does not contain a release.
+func (c *GitHubContext) Release() (tag, name string, ok bool) {
+	if c == nil || c.EventName != "release" || c.Event == nil {
+		return "", "", false
+	}
+
+	release, ok := c.Event["release"].(map[string]any)
+	if !ok {
+		return "", "", false
+	}
+
+	tag, _ = release["tag_name"].(string)
+	name, _ = release["name"].(string)
+	return tag, name, true
+}
+
+// ContextJSON returns the context as JSON in the same shape as the workflow
+// expression "toJSON(github)". Numeric run fields are encoded as strings to
+// match the runner. The event payload is included as both "event" and
+// "payload", the latter matching the context used by actions/github-script.
+func (c *GitHubContext) ContextJSON() ([]byte, error) {
+	if c == nil {
+		return nil, fmt.Errorf("missing context")
+	}
+
+	event := c.Event
+	if event == nil {
+		event = map[string]any{}
+	}
+
+	m := map[string]any{
+		"action":            c.Action,
+		"action_path":       c.ActionPath,
+		"action_repository": c.ActionRepository,
+		"actor":             c.Actor,
+		"actor_id":          c.ActorID,
+		"api_url":           c.APIURL,
+		"base_ref":          c.BaseRef,
+		"env":               c.Env,
+		"event":             event,
+		"event_name":        c.EventName,
+		"event_path":        c.EventPath,
+		"graphql_url":       c.GraphqlURL,
+		"head_ref":          c.HeadRef,
+		"job":               c.Job,
+		"path":              c.Path,
+		"payload":           event,
+		"ref":               c.Ref,
+		"ref_name":          c.RefName,
+		"ref_protected":     c.RefProtected,
+		"ref_type":          c.RefType,
+		"repository":        c.Repository,
+		"repository_owner":  c.RepositoryOwner,
+		"retention_days":    strconv.FormatInt(c.RetentionDays, 10),
+		"run_attempt":       strconv.FormatInt(c.RunAttempt, 10),
+		"run_id":            strconv.FormatInt(c.RunID, 10),
+		"run_number":        strconv.FormatInt(c.RunNumber, 10),
+		"server_url":        c.ServerURL,
+		"sha":               c.SHA,
+		"step_summary":      c.StepSummary,
+		"workflow":          c.Workflow,
+		"workflow_ref":      c.WorkflowRef,
+		"workspace":         c.Workspace,
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal context: %w", err)
+	}
+	return b, nil
+}
+
 func parseBool(v string) (bool, error) {
 	if v == "" {
 		return false, nil
@@ -544,6 +3448,19 @@ func parseBool(v string) (bool, error) {
 	return strconv.ParseBool(v)
 }
 
+// parseYAMLBool parses v using the YAML 1.1 truthy set accepted by GetBool. An
+// empty value is false.
+func parseYAMLBool(v string) (bool, error) {
+	switch strings.ToLower(v) {
+	case "", "false", "no", "off", "0":
+		return false, nil
+	case "true", "yes", "on", "1":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid boolean %q", v)
+	}
+}
+
 func parseInt(v string) (int64, error) {
 	if v == "" {
 		return 0, nil
@@ -554,6 +3471,17 @@ func parseInt(v string) (int64, error) {
 // Context returns the context of current action with the payload object
 // that triggered the workflow
 func (c *Action) Context() (*GitHubContext, error) {
+	return c.ContextWith(context.Background())
+}
+
+// ContextWith is like Context, but honors cancellation and deadlines of the
+// given context. It returns the context's error if it is done before the
+// environment is processed or before or after the event file is read.
+func (c *Action) ContextWith(ctx context.Context) (*GitHubContext, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to build context: %w", err)
+	}
+
 	var merr error
 	githubContext := &GitHubContext{
 		APIURL:     "https://api.github.com",
@@ -662,15 +3590,25 @@ func (c *Action) Context() (*GitHubContext, error) {
 	if v := c.getenv("GITHUB_WORKFLOW"); v != "" {
 		githubContext.Workflow = v
 	}
+	if v := c.getenv("GITHUB_WORKFLOW_REF"); v != "" {
+		githubContext.WorkflowRef = v
+	}
 	if v := c.getenv("GITHUB_WORKSPACE"); v != "" {
 		githubContext.Workspace = v
 	}
 
 	if githubContext.EventPath != "" {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read event file: %w", err)
+		}
+
 		eventData, err := os.ReadFile(githubContext.EventPath)
 		if err != nil && !os.IsNotExist(err) {
 			return nil, fmt.Errorf("could not read event file: %w", err)
 		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read event file: %w", err)
+		}
 		if eventData != nil {
 			if err := json.Unmarshal(eventData, &githubContext.Event); err != nil {
 				return nil, fmt.Errorf("failed to unmarshal event payload: %w", err)
@@ -678,5 +3616,82 @@ func (c *Action) Context() (*GitHubContext, error) {
 		}
 	}
 
+	if c.eventOverlay != nil {
+		if githubContext.Event == nil {
+			githubContext.Event = make(map[string]any, len(c.eventOverlay))
+		}
+		mergeEvent(githubContext.Event, c.eventOverlay)
+	}
+
 	return githubContext, merr
 }
+
+// mergeEvent deep-merges src into dst. Nested objects are merged recursively;
+// all other values in src replace those in dst. Nested objects from src are
+// copied so that later changes to dst do not modify src.
+func mergeEvent(dst, src map[string]any) {
+	for k, sv := range src {
+		sm, ok := sv.(map[string]any)
+		if !ok {
+			dst[k] = sv
+			continue
+		}
+
+		dm, ok := dst[k].(map[string]any)
+		if !ok {
+			dm = make(map[string]any, len(sm))
+			dst[k] = dm
+		}
+		mergeEvent(dm, sm)
+	}
+}
+
+// RunnerContext is the runner environment of the current job, read from the
+// RUNNER_* environment variables.
+//
+// See: https://docs.github.com/en/actions/learn-github-actions/variables#default-environment-variables
+type RunnerContext struct {
+	// OS is the operating system of the runner: "Linux", "Windows", or "macOS".
+	OS string
+
+	// Arch is the architecture of the runner: "X86", "X64", "ARM", or "ARM64".
+	Arch string
+
+	Name      string
+	Temp      string
+	ToolCache string
+
+	// Debug is true if debug logging is enabled on the runner.
+	Debug bool
+}
+
+// RunnerContext returns the runner environment of the current job. It returns
+// an error if RUNNER_DEBUG is set but is not a boolean, along with the context
+// populated from the remaining variables.
+func (c *Action) RunnerContext() (*RunnerContext, error) {
+	var merr error
+	runnerContext := &RunnerContext{}
+
+	if v := c.getenv("RUNNER_OS"); v != "" {
+		runnerContext.OS = v
+	}
+	if v := c.getenv("RUNNER_ARCH"); v != "" {
+		runnerContext.Arch = v
+	}
+	if v := c.getenv("RUNNER_NAME"); v != "" {
+		runnerContext.Name = v
+	}
+	if v := c.getenv("RUNNER_TEMP"); v != "" {
+		runnerContext.Temp = v
+	}
+	if v := c.getenv("RUNNER_TOOL_CACHE"); v != "" {
+		runnerContext.ToolCache = v
+	}
+	if v, err := parseBool(c.getenv("RUNNER_DEBUG")); err == nil {
+		runnerContext.Debug = v
+	} else {
+		merr = errors.Join(merr, err)
+	}
+
+	return runnerContext, merr
+}
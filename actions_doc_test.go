@@ -16,6 +16,8 @@ package githubactions_test
 
 import (
 	"context"
+	"fmt"
+	"io"
 
 	"github.com/sethvargo/go-githubactions"
 )
@@ -43,6 +45,14 @@ func ExampleAction_GetInput() {
 	a.GetInput("foo")
 }
 
+func ExampleAction_GetState() {
+	a := githubactions.New()
+	a.SaveState("pid", "1234")
+
+	// in the post step
+	a.GetState("pid")
+}
+
 func ExampleAction_Group() {
 	a := githubactions.New()
 	a.Group("My group")
@@ -141,6 +151,16 @@ func ExampleAction_Errorf_fieldsSlice() {
 	a.WithFieldsSlice(s).Errorf("an error message")
 }
 
+func ExampleAction_AnnotateError() {
+	a := githubactions.New()
+	a.AnnotateError(githubactions.Annotation{
+		Title:   "Syntax error",
+		File:    "app.go",
+		Line:    100,
+		EndLine: 102,
+	}, "an error message")
+}
+
 func ExampleAction_SetEnv() {
 	a := githubactions.New()
 	a.SetEnv("MY_THING", "my value")
@@ -161,3 +181,23 @@ func ExampleAction_GetIDToken() {
 	}
 	_ = token
 }
+
+func ExampleRecorder() {
+	var r githubactions.Recorder
+	a := githubactions.New(
+		githubactions.WithWriter(io.Discard),
+		githubactions.WithRecorder(&r),
+	)
+
+	a.Group("My group")
+	a.Infof("hello %s", "world")
+	a.EndGroup()
+
+	for _, line := range r.Lines() {
+		fmt.Println(line)
+	}
+	// Output:
+	// ::group::My group
+	// hello world
+	// ::endgroup::
+}
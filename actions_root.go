@@ -16,6 +16,8 @@ package githubactions
 
 import (
 	"context"
+	"io"
+	"time"
 )
 
 var (
@@ -27,6 +29,12 @@ func IssueCommand(cmd *Command) {
 	defaultAction.IssueCommand(cmd)
 }
 
+// IssueCommandErr issues an arbitrary GitHub actions Command, returning an
+// error if it cannot be written.
+func IssueCommandErr(cmd *Command) error {
+	return defaultAction.IssueCommandErr(cmd)
+}
+
 // IssueFileCommand issues a new GitHub actions Command using environment files.
 func IssueFileCommand(cmd *Command) {
 	defaultAction.IssueFileCommand(cmd)
@@ -38,6 +46,17 @@ func AddMask(p string) {
 	defaultAction.AddMask(p)
 }
 
+// AddMaskIfSecret masks the given string only if it looks like a secret.
+func AddMaskIfSecret(s string) bool {
+	return defaultAction.AddMaskIfSecret(s)
+}
+
+// FlushMasks flushes the output stream so that registered masks reach the
+// runner before subsequent output.
+func FlushMasks() {
+	defaultAction.FlushMasks()
+}
+
 // AddMatcher adds a new matcher with the given file path.
 func AddMatcher(p string) {
 	defaultAction.AddMatcher(p)
@@ -58,11 +77,132 @@ func SaveState(k, v string) {
 	defaultAction.SaveState(k, v)
 }
 
+// SaveStateErr saves state like SaveState, but returns an error instead of
+// panicking.
+func SaveStateErr(k, v string) error {
+	return defaultAction.SaveStateErr(k, v)
+}
+
+// GetState gets the state saved with SaveState by the given name.
+func GetState(name string) string {
+	return defaultAction.GetState(name)
+}
+
 // GetInput gets the input by the given name.
 func GetInput(i string) string {
 	return defaultAction.GetInput(i)
 }
 
+// GetInputFirst returns the value of the first non-empty input among names and
+// the name that provided it.
+func GetInputFirst(names ...string) (value, which string) {
+	return defaultAction.GetInputFirst(names...)
+}
+
+// GetRequiredInput gets the input by the given name, returning an error if it
+// is not defined.
+func GetRequiredInput(i string) (string, error) {
+	return defaultAction.GetRequiredInput(i)
+}
+
+// GetMultilineInput gets the input by the given name and splits it into
+// non-empty, trimmed lines.
+func GetMultilineInput(i string) []string {
+	return defaultAction.GetMultilineInput(i)
+}
+
+// GetCSVInput gets the input by the given name and splits it into non-empty,
+// trimmed comma-separated values.
+func GetCSVInput(i string) []string {
+	return defaultAction.GetCSVInput(i)
+}
+
+// GetBool gets the input by the given name and parses it as a YAML-style
+// boolean, such as "yes" or "off". See Action.GetBool for the accepted values.
+func GetBool(i string) (bool, error) {
+	return defaultAction.GetBool(i)
+}
+
+// GetInt gets the input by the given name and parses it as an integer.
+func GetInt(i string) (int64, error) {
+	return defaultAction.GetInt(i)
+}
+
+// GetIntInputInRange gets the required input by the given name and parses it
+// as an integer between min and max, inclusive.
+func GetIntInputInRange(i string, min, max int64) (int64, error) {
+	return defaultAction.GetIntInputInRange(i, min, max)
+}
+
+// GetInputSemver gets the required input by the given name and parses it as a
+// semantic version.
+func GetInputSemver(i string) (*Version, error) {
+	return defaultAction.GetInputSemver(i)
+}
+
+// GetFloat gets the input by the given name and parses it as a floating point
+// number.
+func GetFloat(i string) (float64, error) {
+	return defaultAction.GetFloat(i)
+}
+
+// GetDuration gets the input by the given name and parses it as a duration.
+func GetDuration(i string) (time.Duration, error) {
+	return defaultAction.GetDuration(i)
+}
+
+// GetBoolInput gets the input by the given name and parses it with
+// strconv.ParseBool. See Action.GetBoolInput for how it differs from GetBool.
+func GetBoolInput(i string) (bool, error) {
+	return defaultAction.GetBoolInput(i)
+}
+
+// GetBoolInputStrict gets the input by the given name and parses it as a
+// boolean, accepting only "true" or "false".
+func GetBoolInputStrict(i string) (bool, error) {
+	return defaultAction.GetBoolInputStrict(i)
+}
+
+// UnmarshalInputs populates the fields of the struct pointed to by v from the
+// inputs named by their "actions" struct tags.
+func UnmarshalInputs(v any) error {
+	return defaultAction.UnmarshalInputs(v)
+}
+
+// GetInputTime gets the input by the given name and parses it as a time using
+// the given layout, or time.RFC3339 if the layout is empty.
+func GetInputTime(i, layout string) (time.Time, error) {
+	return defaultAction.GetInputTime(i, layout)
+}
+
+// GetSecret gets the input by the given name and masks its value in the logs.
+func GetSecret(i string) string {
+	return defaultAction.GetSecret(i)
+}
+
+// GetSecretsInput gets the input by the given name, decodes it as a JSON object
+// of secrets, and masks every value.
+func GetSecretsInput(i string) (map[string]string, error) {
+	return defaultAction.GetSecretsInput(i)
+}
+
+// EnvSnapshot returns the environment variables whose names start with any of
+// the given prefixes, with masked values redacted.
+func EnvSnapshot(prefixes ...string) map[string]string {
+	return defaultAction.EnvSnapshot(prefixes...)
+}
+
+// IsDebug returns true if debug logging is enabled on the runner.
+func IsDebug() bool {
+	return defaultAction.IsDebug()
+}
+
+// LogInputs prints all inputs the action received inside a collapsed group
+// when debug logging is enabled.
+func LogInputs() {
+	defaultAction.LogInputs()
+}
+
 // Group starts a new collapsable region up to the next ungroup invocation.
 func Group(t string) {
 	defaultAction.Group(t)
@@ -73,12 +213,59 @@ func EndGroup() {
 	defaultAction.EndGroup()
 }
 
+// StartTimer starts timing the action and returns a func that sets the elapsed
+// time as the "duration_ms" output and logs it.
+func StartTimer() func() {
+	return defaultAction.StartTimer()
+}
+
+// GroupFunc runs fn inside a collapsed group with the given title, closing the
+// group even if fn panics.
+func GroupFunc(title string, fn func()) {
+	defaultAction.GroupFunc(title, fn)
+}
+
+// Step runs fn inside a collapsed group with the given name and reports any
+// returned error.
+func Step(name string, fn func() error) error {
+	return defaultAction.Step(name, fn)
+}
+
+// LogBlock prints the content inside a collapsed group with the given title,
+// without interpreting any workflow commands in the content.
+func LogBlock(title, content string) {
+	defaultAction.LogBlock(title, content)
+}
+
+// ReportTestFailure emits an error annotation for the failed test followed by
+// its output in a collapsed group.
+func ReportTestFailure(name, output string) {
+	defaultAction.ReportTestFailure(name, output)
+}
+
 // AddStepSummary writes the given markdown to the job summary. If a job summary
 // already exists, this value is appended.
 func AddStepSummary(markdown string) {
 	defaultAction.AddStepSummary(markdown)
 }
 
+// OverwriteStepSummary replaces the contents of the job summary with the given
+// markdown.
+func OverwriteStepSummary(markdown string) {
+	defaultAction.OverwriteStepSummary(markdown)
+}
+
+// RemoveStepSummary truncates the job summary file to empty.
+func RemoveStepSummary() error {
+	return defaultAction.RemoveStepSummary()
+}
+
+// AddStepSummaryErr writes the given markdown to the job summary, returning an
+// error if the summary would exceed the size limit or cannot be written.
+func AddStepSummaryErr(markdown string) error {
+	return defaultAction.AddStepSummaryErr(markdown)
+}
+
 // AddStepSummaryTemplate adds a summary template by parsing the given Go
 // template using html/template with the given input data. See AddStepSummary
 // for caveats.
@@ -88,16 +275,110 @@ func AddStepSummaryTemplate(tmpl string, data any) error {
 	return defaultAction.AddStepSummaryTemplate(tmpl, data)
 }
 
+// AddStepSummaryTextTemplate adds a summary template by parsing the given Go
+// template using text/template, without HTML-escaping the data.
+func AddStepSummaryTextTemplate(tmpl string, data any) error {
+	return defaultAction.AddStepSummaryTextTemplate(tmpl, data)
+}
+
+// AddStepSummaryImage appends an image with the given dimensions to the job
+// summary.
+func AddStepSummaryImage(src, alt string, width, height int) {
+	defaultAction.AddStepSummaryImage(src, alt, width, height)
+}
+
+// WriteStepSummaryTo copies the current contents of the job summary to the
+// given writer.
+func WriteStepSummaryTo(w io.Writer) error {
+	return defaultAction.WriteStepSummaryTo(w)
+}
+
 // SetEnv sets an environment variable.
 func SetEnv(k, v string) {
 	defaultAction.SetEnv(k, v)
 }
 
+// SetEnvErr sets an environment variable like SetEnv, but returns an error
+// instead of panicking.
+func SetEnvErr(k, v string) error {
+	return defaultAction.SetEnvErr(k, v)
+}
+
+// SetEnvs sets each of the given environment variables.
+func SetEnvs(m map[string]string) error {
+	return defaultAction.SetEnvs(m)
+}
+
 // SetOutput sets an output parameter.
 func SetOutput(k, v string) {
 	defaultAction.SetOutput(k, v)
 }
 
+// SetOutputErr sets an output parameter like SetOutput, but returns an error
+// instead of panicking.
+func SetOutputErr(k, v string) error {
+	return defaultAction.SetOutputErr(k, v)
+}
+
+// TrySetOutput sets an output parameter, returning an error if the name is
+// invalid or the value is too large.
+func TrySetOutput(k, v string) error {
+	return defaultAction.TrySetOutput(k, v)
+}
+
+// SetOutputJSONSafe sets an output parameter to the compact JSON encoding of v,
+// so it can be consumed with fromJSON in subsequent steps.
+func SetOutputJSONSafe(name string, v any) error {
+	return defaultAction.SetOutputJSONSafe(name, v)
+}
+
+// SetResult sets a machine-readable "result" output containing the status and
+// details as JSON.
+func SetResult(status string, details map[string]string) error {
+	return defaultAction.SetResult(status, details)
+}
+
+// SetOutputIfChanged sets the output parameter and saves it as state only if
+// the value differs from the previously saved state.
+func SetOutputIfChanged(k, v string) (bool, error) {
+	return defaultAction.SetOutputIfChanged(k, v)
+}
+
+// DeclareOutputs declares outputs that the action must set before it exits.
+func DeclareOutputs(names ...string) {
+	defaultAction.DeclareOutputs(names...)
+}
+
+// VerifyOutputs returns an error listing every declared output that was not
+// set.
+func VerifyOutputs() error {
+	return defaultAction.VerifyOutputs()
+}
+
+// FinalizeOutputs logs the outputs set by the action when debug logging is
+// enabled and flushes the output stream.
+func FinalizeOutputs() error {
+	return defaultAction.FinalizeOutputs()
+}
+
+// SetOutputDerived masks the secret and sets the output to the value derived
+// from it.
+func SetOutputDerived(name, secret string, derive func(string) string) {
+	defaultAction.SetOutputDerived(name, secret, derive)
+}
+
+// SetOutputs sets each of the given output parameters with a single write to
+// the output file.
+func SetOutputs(kv map[string]string) {
+	defaultAction.SetOutputs(kv)
+}
+
+// SetOutputsWithSummary sets each of the given output parameters and appends a
+// table of the outputs to the job summary.
+func SetOutputsWithSummary(m map[string]string) {
+	defaultAction.SetOutputsWithSummary(m)
+}
+
 // Debugf prints a debug-level message. The arguments follow the standard Printf
 // arguments.
 func Debugf(msg string, args ...any) {
@@ -110,12 +391,62 @@ func Noticef(msg string, args ...any) {
 	defaultAction.Noticef(msg, args...)
 }
 
+// NoticeResources prints a notice-level message summarizing the given
+// resources, sorted by name.
+func NoticeResources(title string, resources map[string]string) {
+	defaultAction.NoticeResources(title, resources)
+}
+
+// NoticeResourcesWithSummary prints a notice-level message summarizing the
+// given resources and appends a table of them to the job summary.
+func NoticeResourcesWithSummary(title string, resources map[string]string) {
+	defaultAction.NoticeResourcesWithSummary(title, resources)
+}
+
 // Errorf prints a error-level message. The arguments follow the standard Printf
 // arguments.
 func Errorf(msg string, args ...any) {
 	defaultAction.Errorf(msg, args...)
 }
 
+// AnnotateError emits an error-level annotation with the given properties.
+func AnnotateError(a Annotation, msg string) {
+	defaultAction.AnnotateError(a, msg)
+}
+
+// AnnotateWarning emits a warning-level annotation with the given properties.
+func AnnotateWarning(a Annotation, msg string) {
+	defaultAction.AnnotateWarning(a, msg)
+}
+
+// AnnotateNotice emits a notice-level annotation with the given properties.
+func AnnotateNotice(a Annotation, msg string) {
+	defaultAction.AnnotateNotice(a, msg)
+}
+
+// AnnotatePRLine emits an annotation of the given level anchored to a single
+// line of a file.
+func AnnotatePRLine(file string, line int, level AnnotationLevel, msg string) {
+	defaultAction.AnnotatePRLine(file, line, level, msg)
+}
+
+// AnnotateFile emits an annotation of the given level scoped to a file, without
+// a line.
+func AnnotateFile(file string, level AnnotationLevel, msg string) {
+	defaultAction.AnnotateFile(file, level, msg)
+}
+
+// NoticeFindings emits one notice-level annotation per finding.
+func NoticeFindings(findings []Finding) {
+	defaultAction.NoticeFindings(findings)
+}
+
+// NoticeFindingsByFile emits one notice-level annotation per finding, grouped
+// by file.
+func NoticeFindingsByFile(findings []Finding) {
+	defaultAction.NoticeFindingsByFile(findings)
+}
+
 // Fatalf prints a error-level message and exits. This is equivalent to Errorf
 // followed by os.Exit(1).
 func Fatalf(msg string, args ...any) {
@@ -128,12 +459,23 @@ func Infof(msg string, args ...any) {
 	defaultAction.Infof(msg, args...)
 }
 
+// Infoft renders the given template using text/template and prints the result
+// without any level annotations.
+func Infoft(tmpl string, data any) error {
+	return defaultAction.Infoft(tmpl, data)
+}
+
 // Warningf prints a warning-level message. The arguments follow the standard
 // Printf arguments.
 func Warningf(msg string, args ...any) {
 	defaultAction.Warningf(msg, args...)
 }
 
+// WarningWithDocs prints a warning-level message that links to documentation.
+func WarningWithDocs(msg, docURL string) {
+	defaultAction.WarningWithDocs(msg, docURL)
+}
+
 // WithFieldsSlice includes the provided fields in log output. "f" must be a
 // slice of k=v pairs. The given slice will be sorted.
 func WithFieldsSlice(f []string) *Action {
@@ -146,11 +488,35 @@ func WithFieldsMap(m map[string]string) *Action {
 	return defaultAction.WithFieldsMap(m)
 }
 
+// WithExtraFieldsMap merges the provided fields into the existing fields
+// included in log output. Keys in "m" take precedence over existing keys.
+func WithExtraFieldsMap(m map[string]string) *Action {
+	return defaultAction.WithExtraFieldsMap(m)
+}
+
 // GetIDToken returns the GitHub OIDC token from the GitHub Actions runtime.
 func GetIDToken(ctx context.Context, audience string) (string, error) {
 	return defaultAction.GetIDToken(ctx, audience)
 }
 
+// GetIDTokenClaims mints an OIDC token and returns its claims without
+// validating the token.
+func GetIDTokenClaims(ctx context.Context, audience string) (map[string]any, error) {
+	return defaultAction.GetIDTokenClaims(ctx, audience)
+}
+
+// HashFiles returns a SHA-256 hex digest of the files in the workspace that
+// match any of the given glob patterns.
+func HashFiles(globs ...string) (string, error) {
+	return defaultAction.HashFiles(globs...)
+}
+
 func Context() (*GitHubContext, error) {
 	return defaultAction.Context()
 }
+
+// ContextWith returns the context of the current action, honoring cancellation
+// of ctx.
+func ContextWith(ctx context.Context) (*GitHubContext, error) {
+	return defaultAction.ContextWith(ctx)
+}
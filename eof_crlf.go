@@ -17,4 +17,6 @@
 
 package githubactions
 
+// EOF is the default line ending written after commands and messages. It can be
+// overridden per Action with WithEOL.
 const EOF = "\r\n"
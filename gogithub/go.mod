@@ -0,0 +1,13 @@
+module github.com/sethvargo/go-githubactions/gogithub
+
+go 1.21
+
+// go-githubactions v1.4.0 is the first release with GitHubContext.APIEndpoints.
+// Tag it before tagging this module. Within this repository, the go.work file
+// at the root resolves it to the local copy instead.
+require (
+	github.com/google/go-github/v66 v66.0.0
+	github.com/sethvargo/go-githubactions v1.4.0
+)
+
+require github.com/google/go-querystring v1.1.0 // indirect
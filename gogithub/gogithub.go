@@ -0,0 +1,47 @@
+// Copyright 2020 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gogithub constructs go-github clients for the GitHub instance running
+// a workflow. It is a separate module so that the core githubactions package
+// does not depend on go-github.
+package gogithub
+
+import (
+	"fmt"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// NewClient returns a go-github client authenticated with the given token and
+// configured for the instance described by the context, including GitHub
+// Enterprise Server. The endpoints are taken from GitHubContext.APIEndpoints.
+// If token is empty, the client is unauthenticated.
+func NewClient(ghc *githubactions.GitHubContext, token string) (*github.Client, error) {
+	client := github.NewClient(nil)
+	if token != "" {
+		client = client.WithAuthToken(token)
+	}
+
+	baseURL, uploadURL := ghc.APIEndpoints()
+	if baseURL == client.BaseURL.String() {
+		return client, nil
+	}
+
+	client, err := client.WithEnterpriseURLs(baseURL, uploadURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure enterprise urls: %w", err)
+	}
+	return client, nil
+}
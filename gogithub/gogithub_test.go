@@ -0,0 +1,70 @@
+// Copyright 2020 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gogithub
+
+import (
+	"testing"
+
+	"github.com/sethvargo/go-githubactions"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		context   *githubactions.GitHubContext
+		expBase   string
+		expUpload string
+	}{
+		{
+			name: "github",
+			context: &githubactions.GitHubContext{
+				APIURL:    "https://api.github.com",
+				ServerURL: "https://github.com",
+			},
+			expBase:   "https://api.github.com/",
+			expUpload: "https://uploads.github.com/",
+		},
+		{
+			name: "enterprise",
+			context: &githubactions.GitHubContext{
+				APIURL:    "https://github.example.com/api/v3",
+				ServerURL: "https://github.example.com",
+			},
+			expBase:   "https://github.example.com/api/v3/",
+			expUpload: "https://github.example.com/api/uploads/",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewClient(tc.context, "token")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := client.BaseURL.String(), tc.expBase; got != want {
+				t.Errorf("expected %q to be %q", got, want)
+			}
+			if got, want := client.UploadURL.String(), tc.expUpload; got != want {
+				t.Errorf("expected %q to be %q", got, want)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package githubactions
 import (
 	"io"
 	"net/http"
+	"time"
 )
 
 // Option is a modifier for an Action.
@@ -31,6 +32,18 @@ func WithWriter(w io.Writer) Option {
 	}
 }
 
+// WithErrWriter sets a separate writer for error and warning commands, such as
+// those written by Errorf, Warningf, Fatalf, AnnotateError, and
+// AnnotateWarning. This keeps annotations visible when the output stream is
+// consumed as data. If unset, or set to nil, all commands are written to the
+// writer set with WithWriter.
+func WithErrWriter(w io.Writer) Option {
+	return func(a *Action) *Action {
+		a.errWriter = w
+		return a
+	}
+}
+
 // WithFields sets the extra command field on an Action.
 func WithFields(fields CommandProperties) Option {
 	return func(a *Action) *Action {
@@ -48,12 +61,214 @@ func WithGetenv(getenv GetenvFunc) Option {
 	}
 }
 
+// WithEnviron sets the `Environ` function on an Action. By default, this will
+// be `os.Environ` from the standard library.
+func WithEnviron(environ EnvironFunc) Option {
+	return func(a *Action) *Action {
+		a.environ = environ
+		return a
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client on the action. This is only used
 // when the action makes output HTTP requests (such as generating an OIDC
-// token).
+// token). The default client retries transient failures of idempotent
+// requests; to keep that behavior with a custom client, wrap its transport in a
+// RetryTransport.
 func WithHTTPClient(c *http.Client) Option {
 	return func(a *Action) *Action {
 		a.httpClient = c
 		return a
 	}
 }
+
+// WithSecretLeakDetection enables or disables warnings for values previously
+// registered with AddMask that are written via IssueCommand or Infof. Masked
+// values are always redacted from the output stream; when enabled, a warning is
+// also emitted so the leak can be fixed at its source.
+func WithSecretLeakDetection(enabled bool) Option {
+	return func(a *Action) *Action {
+		a.leakDetection = enabled
+		return a
+	}
+}
+
+// WithRecorder sets a Recorder on the Action that receives a copy of every
+// line written to the output stream. Output is still written to the writer.
+func WithRecorder(r *Recorder) Option {
+	return func(a *Action) *Action {
+		a.recorder = r
+		return a
+	}
+}
+
+// WithSARIFCollector sets a SARIFCollector on the Action that records every
+// annotation emitted with AnnotateError, AnnotateWarning, AnnotateNotice,
+// AnnotatePRLine, or AnnotateFile. Annotations are still written to the output
+// stream.
+func WithSARIFCollector(s *SARIFCollector) Option {
+	return func(a *Action) *Action {
+		a.sarif = s
+		return a
+	}
+}
+
+// WithExpressionWarnings enables or disables warnings when an input returned by
+// GetInput contains a literal "${{". This almost always indicates that the
+// workflow passed an expression that was not interpolated.
+func WithExpressionWarnings(enabled bool) Option {
+	return func(a *Action) *Action {
+		a.expressionWarnings = enabled
+		return a
+	}
+}
+
+// WithEventOverlay sets values that are deep-merged into the event payload
+// returned by Context, after the file at GITHUB_EVENT_PATH is parsed. Nested
+// objects are merged and all other values are replaced. This is primarily
+// useful for tests or wrappers that need to inject or override event fields.
+func WithEventOverlay(overlay map[string]any) Option {
+	return func(a *Action) *Action {
+		a.eventOverlay = overlay
+		return a
+	}
+}
+
+// WithSecretHeuristic sets the thresholds AddMaskIfSecret uses to decide if a
+// value looks like a secret: the minimum length and the minimum Shannon entropy
+// in bits per character. Values less than or equal to zero use the defaults.
+func WithSecretHeuristic(minLength int, minEntropy float64) Option {
+	return func(a *Action) *Action {
+		a.secretMinLength = minLength
+		a.secretMinEntropy = minEntropy
+		return a
+	}
+}
+
+// WithCommandJSONWriter sets a writer that receives every command issued with
+// IssueCommand as newline-delimited JSON, in addition to the workflow command
+// written to the output stream. Each line is an object with "name",
+// "properties", and "message" keys. The message is not escaped.
+func WithCommandJSONWriter(w io.Writer) Option {
+	return func(a *Action) *Action {
+		a.jsonWriter = w
+		return a
+	}
+}
+
+// WithStepSummaryTruncation enables or disables truncation of step summaries
+// that would exceed the 1 MiB limit. When enabled, AddStepSummary cuts the
+// markdown at the end of the last complete block (such as a heading, paragraph,
+// or table) that fits and appends a "(truncated)" note, instead of producing a
+// summary that is cut mid-block or rejected.
+func WithStepSummaryTruncation(enabled bool) Option {
+	return func(a *Action) *Action {
+		a.summaryTruncation = enabled
+		return a
+	}
+}
+
+// WithStepSummaryFallback sets a writer that receives step summaries written
+// with AddStepSummary and related methods when GITHUB_STEP_SUMMARY is not set,
+// such as on older runners or when running locally. For example, use
+// os.Stderr to see summaries while developing an action. By default, such
+// summaries are discarded.
+func WithStepSummaryFallback(w io.Writer) Option {
+	return func(a *Action) *Action {
+		a.summaryFallback = w
+		return a
+	}
+}
+
+// WithStartupContextLog enables or disables logging a one-line summary of the
+// workflow context when the Action is created with New, such as:
+//
+//	repo=owner/name ref=refs/heads/main sha=abcd1234 event=push run=56/1
+//
+// The summary is only printed when debug logging is enabled on the runner.
+func WithStartupContextLog(enabled bool) Option {
+	return func(a *Action) *Action {
+		a.startupContextLog = enabled
+		return a
+	}
+}
+
+// WithMaxOutputSize sets the maximum size, in bytes, of a single output value
+// accepted by TrySetOutput. Values less than or equal to zero use the default
+// of 1 MiB.
+func WithMaxOutputSize(n int) Option {
+	return func(a *Action) *Action {
+		a.maxOutputSize = n
+		return a
+	}
+}
+
+// WithEOL sets the line ending written after each command, message, and
+// environment file entry. By default, this is EOF, which is "\r\n" on Windows
+// and "\n" elsewhere.
+func WithEOL(eol string) Option {
+	return func(a *Action) *Action {
+		a.eol = eol
+		return a
+	}
+}
+
+// WithDeprecatedCommandWarnings enables or disables warnings when IssueCommand
+// is called with a deprecated stdout command, such as "set-env" or "add-path".
+// The warning names the method that writes the equivalent environment file. The
+// command is still issued.
+func WithDeprecatedCommandWarnings(enabled bool) Option {
+	return func(a *Action) *Action {
+		a.deprecationWarnings = enabled
+		return a
+	}
+}
+
+// WithRequireActionsEnv makes New print an error and exit if the GITHUB_ACTIONS
+// environment variable is not "true". This prevents an action that must only
+// run inside the runner from being executed locally by accident. The check
+// happens after all options are applied and uses the action's GetenvFunc.
+func WithRequireActionsEnv(enabled bool) Option {
+	return func(a *Action) *Action {
+		a.requireActionsEnv = enabled
+		return a
+	}
+}
+
+// WithIDTokenRetry configures GetIDToken to make up to the given number of
+// attempts, including the first, to mint a token. Transport errors and non-2xx
+// responses are retried with exponential backoff starting at base, except for
+// 401 and 403 responses, which are returned immediately. If base is zero, a
+// default of 250ms is used. Retries stop when the context passed to GetIDToken
+// is done.
+//
+// When attempts is positive, it replaces the retries of the default HTTP client,
+// or of any client whose transport is a RetryTransport, so each attempt makes
+// exactly one request. Set attempts to 1 to disable retries entirely.
+func WithIDTokenRetry(attempts int, base time.Duration) Option {
+	return func(a *Action) *Action {
+		a.idTokenRetryAttempts = attempts
+		a.idTokenRetryBase = base
+		return a
+	}
+}
+
+// WithClock sets the func used to read the current time, such as for
+// StartTimer. This is primarily useful for testing. By default, time.Now is
+// used.
+func WithClock(fn func() time.Time) Option {
+	return func(a *Action) *Action {
+		a.clock = fn
+		return a
+	}
+}
+
+// WithTee sets a writer that receives a copy of every command and message
+// written to the output stream, such as an *os.File to archive the log as an
+// artifact. The caller owns the writer and is responsible for closing it.
+func WithTee(w io.Writer) Option {
+	return func(a *Action) *Action {
+		a.tee = w
+		return a
+	}
+}
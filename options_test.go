@@ -16,7 +16,11 @@ package githubactions
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestWithWriter(t *testing.T) {
@@ -37,6 +41,46 @@ func TestWithWriter(t *testing.T) {
 	}
 }
 
+func TestWithErrWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("set", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout, stderr bytes.Buffer
+		a := New(WithWriter(&stdout), WithErrWriter(&stderr))
+		a.Infof("info")
+		a.Noticef("notice")
+		a.Warningf("warning")
+		a.WithFieldsMap(map[string]string{"file": "app.go"}).Errorf("error")
+		a.AnnotateWarning(Annotation{Line: 1}, "annotation")
+
+		if got, want := stdout.String(), "info"+EOF+"::notice::notice"+EOF; got != want {
+			t.Errorf("expected %q to be %q", got, want)
+		}
+
+		want := "::warning::warning" + EOF +
+			"::error file=app.go::error" + EOF +
+			"::warning line=1::annotation" + EOF
+		if got := stderr.String(); got != want {
+			t.Errorf("expected %q to be %q", got, want)
+		}
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout bytes.Buffer
+		a := New(WithWriter(&stdout), WithErrWriter(nil))
+		a.Warningf("warning")
+		a.Errorf("error")
+
+		if got, want := stdout.String(), "::warning::warning"+EOF+"::error::error"+EOF; got != want {
+			t.Errorf("expected %q to be %q", got, want)
+		}
+	})
+}
+
 func TestWithFields(t *testing.T) {
 	t.Parallel()
 
@@ -69,3 +113,199 @@ func TestWithGetenv(t *testing.T) {
 		t.Errorf("expected %q to be %q", got, want)
 	}
 }
+
+func TestWithEnviron(t *testing.T) {
+	t.Parallel()
+
+	a := &Action{}
+	opt := WithEnviron(func() []string {
+		return []string{"sentinel=true"}
+	})
+
+	opt(a)
+	if got, want := a.environ(), []string{"sentinel=true"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q to be %q", got, want)
+	}
+}
+
+func TestWithSecretLeakDetection(t *testing.T) {
+	t.Parallel()
+
+	a := &Action{}
+	opt := WithSecretLeakDetection(true)
+
+	opt(a)
+	if got, want := a.leakDetection, true; got != want {
+		t.Errorf("expected %t to be %t", got, want)
+	}
+}
+
+func TestWithEOL(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	a := &Action{w: &b}
+	opt := WithEOL("\r\n")
+
+	opt(a)
+	a.IssueCommand(&Command{
+		Name:    "foo",
+		Message: "bar",
+	})
+	a.Infof("baz")
+
+	if got, want := b.String(), "::foo::bar\r\nbaz\r\n"; got != want {
+		t.Errorf("expected %q to be %q", got, want)
+	}
+}
+
+func TestWithDeprecatedCommandWarnings(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		cmd     string
+		enabled bool
+		exp     string
+	}{
+		{
+			name:    "set_env",
+			cmd:     "set-env",
+			enabled: true,
+			exp: "::warning::The \"set-env\" command is deprecated and disabled by the runner, use SetEnv instead" + EOF +
+				"::set-env name=FOO::bar" + EOF,
+		},
+		{
+			name:    "add_path",
+			cmd:     "add-path",
+			enabled: true,
+			exp: "::warning::The \"add-path\" command is deprecated and disabled by the runner, use AddPath instead" + EOF +
+				"::add-path name=FOO::bar" + EOF,
+		},
+		{
+			name:    "disabled",
+			cmd:     "set-env",
+			enabled: false,
+			exp:     "::set-env name=FOO::bar" + EOF,
+		},
+		{
+			name:    "other_command",
+			cmd:     "notice",
+			enabled: true,
+			exp:     "::notice name=FOO::bar" + EOF,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b bytes.Buffer
+			a := New(WithWriter(&b), WithDeprecatedCommandWarnings(tc.enabled))
+			a.IssueCommand(&Command{
+				Name:       tc.cmd,
+				Message:    "bar",
+				Properties: CommandProperties{"name": "FOO"},
+			})
+
+			if got, want := b.String(), tc.exp; got != want {
+				t.Errorf("expected %q to be %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWithRequireActionsEnv(t *testing.T) {
+	// NOTE: This test case cannot be `t.Parallel()` because it patches a
+	//       global `osExit`, so could impact other (concurrent) test runs.
+
+	cases := []struct {
+		name     string
+		value    string
+		expOut   string
+		expCalls []int
+	}{
+		{
+			name:     "present",
+			value:    "true",
+			expOut:   "",
+			expCalls: []int{},
+		},
+		{
+			name:     "absent",
+			value:    "",
+			expOut:   "::error::this action must run in GitHub Actions, but GITHUB_ACTIONS is not \"true\"" + EOF,
+			expCalls: []int{1},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			calls := []int{}
+			finalizer := osExitMock(&calls)
+			defer finalizer()
+
+			var b bytes.Buffer
+			New(
+				WithWriter(&b),
+				WithGetenv(newFakeGetenvFunc(t, "GITHUB_ACTIONS", tc.value)),
+				WithRequireActionsEnv(true),
+			)
+
+			if got, want := b.String(), tc.expOut; got != want {
+				t.Errorf("expected %q to be %q", got, want)
+			}
+			if got, want := calls, tc.expCalls; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %v to be %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := &Action{}
+	opt := WithClock(func() time.Time { return now })
+
+	if got, want := opt(a).now(), now; !got.Equal(want) {
+		t.Errorf("expected %s to be %s", got, want)
+	}
+}
+
+func TestWithTee(t *testing.T) {
+	t.Parallel()
+
+	pth := filepath.Join(t.TempDir(), "log.txt")
+	f, err := os.Create(pth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	a := New(WithWriter(&b), WithTee(f))
+	a.Group("build")
+	a.Infof("building")
+	a.WithFieldsMap(map[string]string{"file": "app.go"}).Warningf("careful")
+	a.EndGroup()
+
+	if err := a.FinalizeOutputs(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(pth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), b.String(); got != want {
+		t.Errorf("expected %q to be %q", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubactions
+
+import (
+	"sync"
+)
+
+// Recorder accumulates the exact lines an Action writes to its output stream,
+// including workflow commands and info messages. It is primarily useful for
+// comparing an action's output against a golden file in tests. Output is still
+// written to the Action's writer. The zero value is ready to use and it is safe
+// for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+// record appends the line to the recorder.
+func (r *Recorder) record(line string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lines = append(r.lines, line)
+}
+
+// Lines returns a copy of the recorded lines, without trailing line endings.
+func (r *Recorder) Lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lines := make([]string, len(r.lines))
+	copy(lines, r.lines)
+	return lines
+}
+
+// Reset discards all recorded lines.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lines = nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubactions
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	var r Recorder
+	a := New(WithWriter(&b), WithRecorder(&r))
+	a.Group("build")
+	a.Infof("building %s", "app")
+	a.WithFieldsMap(map[string]string{"file": "app.go"}).Warningf("careful")
+	a.EndGroup()
+
+	exp := []string{
+		"::group::build",
+		"building app",
+		"::warning file=app.go::careful",
+		"::endgroup::",
+	}
+	if got, want := r.Lines(), exp; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q to be %q", got, want)
+	}
+
+	// expect the output to still be written
+	if got, want := b.String(), "::group::build"+EOF+"building app"+EOF+"::warning file=app.go::careful"+EOF+"::endgroup::"+EOF; got != want {
+		t.Errorf("expected %q to be %q", got, want)
+	}
+
+	r.Reset()
+	if got := r.Lines(); len(got) != 0 {
+		t.Errorf("expected %q to be empty", got)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubactions
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultRetryMaxRetries = 3
+	defaultRetryBackoff    = 250 * time.Millisecond
+)
+
+// RetryTransport is an http.RoundTripper that retries requests which fail with
+// a network error, a 429, or a 5xx response, using exponential backoff. It can
+// wrap any transport and be used with WithHTTPClient. The zero value is ready
+// to use and wraps http.DefaultTransport.
+//
+// Only idempotent requests are retried by default: GET, HEAD, OPTIONS, TRACE,
+// PUT, and DELETE requests, and requests with an "Idempotency-Key" or
+// "X-Idempotency-Key" header. Other requests, such as POST and PATCH, could
+// repeat a side effect if the server processed a request whose response was
+// lost, so they are only retried if RetryNonIdempotent is set.
+//
+// Requests with a body are only retried if the body can be re-read via
+// GetBody, which is set automatically by http.NewRequest for common body types.
+// Each retry sends a clone of the request, so the caller's request is never
+// modified.
+type RetryTransport struct {
+	// Base is the underlying transport. If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// MaxRetries is the maximum number of retries after the initial attempt. If
+	// zero, a default of 3 is used.
+	MaxRetries int
+
+	// Backoff is the delay before the first retry. It doubles after each
+	// attempt. If zero, a default of 250ms is used.
+	Backoff time.Duration
+
+	// RetryNonIdempotent enables retries for requests that are not idempotent,
+	// such as POST and PATCH. Only set this if the server is known to handle
+	// repeated requests safely.
+	RetryNonIdempotent bool
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	maxRetries := t.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultRetryMaxRetries
+	}
+
+	backoff := t.Backoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	if !t.RetryNonIdempotent && !isIdempotent(req) {
+		return base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 {
+			r = req.Clone(ctx)
+			if req.Body != nil && req.Body != http.NoBody {
+				if req.GetBody == nil {
+					return nil, fmt.Errorf("failed to retry request: body cannot be rewound")
+				}
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("failed to rewind request body: %w", err)
+				}
+				r.Body = body
+			}
+		}
+
+		resp, err := base.RoundTrip(r)
+		if attempt >= maxRetries || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		// Drain and close the body so the underlying connection can be reused.
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1000))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// isIdempotent returns true if the request can be safely repeated. It follows
+// the same rules as net/http uses when retrying requests on a reused
+// connection.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	_, ok := req.Header["Idempotency-Key"]
+	if !ok {
+		_, ok = req.Header["X-Idempotency-Key"]
+	}
+	return ok
+}
+
+// shouldRetry returns true if the response or error is considered transient.
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubactions
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name          string
+		method        string
+		header        http.Header
+		nonIdempotent bool
+		failures      int32
+		failStatus    int
+		maxRetries    int
+		expStatus     int
+		expCalls      int32
+	}{
+		{
+			name:       "success",
+			failures:   0,
+			maxRetries: 3,
+			expStatus:  http.StatusOK,
+			expCalls:   1,
+		},
+		{
+			name:       "retries_5xx",
+			failures:   2,
+			failStatus: http.StatusBadGateway,
+			maxRetries: 3,
+			expStatus:  http.StatusOK,
+			expCalls:   3,
+		},
+		{
+			name:       "retries_429",
+			failures:   1,
+			failStatus: http.StatusTooManyRequests,
+			maxRetries: 3,
+			expStatus:  http.StatusOK,
+			expCalls:   2,
+		},
+		{
+			name:       "exhausted",
+			failures:   10,
+			failStatus: http.StatusServiceUnavailable,
+			maxRetries: 2,
+			expStatus:  http.StatusServiceUnavailable,
+			expCalls:   3,
+		},
+		{
+			name:       "no_retry_4xx",
+			failures:   10,
+			failStatus: http.StatusUnauthorized,
+			maxRetries: 3,
+			expStatus:  http.StatusUnauthorized,
+			expCalls:   1,
+		},
+		{
+			name:       "no_retry_post",
+			method:     http.MethodPost,
+			failures:   2,
+			failStatus: http.StatusBadGateway,
+			maxRetries: 3,
+			expStatus:  http.StatusBadGateway,
+			expCalls:   1,
+		},
+		{
+			name:       "no_retry_patch",
+			method:     http.MethodPatch,
+			failures:   2,
+			failStatus: http.StatusBadGateway,
+			maxRetries: 3,
+			expStatus:  http.StatusBadGateway,
+			expCalls:   1,
+		},
+		{
+			name:       "retries_post_idempotency_key",
+			method:     http.MethodPost,
+			header:     http.Header{"Idempotency-Key": []string{"abc"}},
+			failures:   2,
+			failStatus: http.StatusBadGateway,
+			maxRetries: 3,
+			expStatus:  http.StatusOK,
+			expCalls:   3,
+		},
+		{
+			name:          "retries_post_opt_in",
+			method:        http.MethodPost,
+			nonIdempotent: true,
+			failures:      2,
+			failStatus:    http.StatusBadGateway,
+			maxRetries:    3,
+			expStatus:     http.StatusOK,
+			expCalls:      3,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if got, want := string(body), "payload"; got != want {
+					t.Errorf("expected %q to be %q", got, want)
+				}
+
+				if atomic.AddInt32(&calls, 1) <= tc.failures {
+					w.WriteHeader(tc.failStatus)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			t.Cleanup(srv.Close)
+
+			transport := &RetryTransport{
+				MaxRetries:         tc.maxRetries,
+				Backoff:            time.Millisecond,
+				RetryNonIdempotent: tc.nonIdempotent,
+			}
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodPut
+			}
+			req, err := http.NewRequest(method, srv.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range tc.header {
+				req.Header[k] = v
+			}
+			body := req.Body
+
+			// Call the transport directly to check the request is not modified.
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if req.Body != body {
+				t.Errorf("expected request body to not be modified")
+			}
+
+			if got, want := resp.StatusCode, tc.expStatus; got != want {
+				t.Errorf("expected %d to be %d", got, want)
+			}
+			if got, want := atomic.LoadInt32(&calls), tc.expCalls; got != want {
+				t.Errorf("expected %d calls to be %d", got, want)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_RoundTrip_Canceled(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{
+		Transport: &RetryTransport{
+			MaxRetries: 5,
+			Backoff:    time.Hour,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubactions
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// defaultSARIFToolName is the tool name used when the collector does not
+	// specify one.
+	defaultSARIFToolName = "go-githubactions"
+)
+
+// SARIFCollector records annotations so they can be exported as a SARIF 2.1.0
+// document, for example to upload with github/codeql-action/upload-sarif. Set
+// it on an Action with WithSARIFCollector to record every annotation the action
+// emits, or call Add directly. The zero value is ready to use and it is safe for
+// concurrent use.
+type SARIFCollector struct {
+	// ToolName is the name of the tool that produced the results. If empty,
+	// "go-githubactions" is used.
+	ToolName string
+
+	mu      sync.Mutex
+	results []sarifResult
+}
+
+// Add records an annotation of the given level.
+func (s *SARIFCollector) Add(level AnnotationLevel, a Annotation, msg string) {
+	if s == nil {
+		return
+	}
+
+	result := sarifResult{
+		Level:   sarifLevel(level),
+		Message: sarifMessage{Text: msg},
+	}
+	if a.Title != "" {
+		result.Message.Text = a.Title + ": " + msg
+	}
+
+	if a.File != "" {
+		loc := sarifLocation{}
+		loc.PhysicalLocation.ArtifactLocation.URI = a.File
+		if a.Line > 0 {
+			loc.PhysicalLocation.Region = &sarifRegion{
+				StartLine:   a.Line,
+				EndLine:     a.EndLine,
+				StartColumn: a.Col,
+				EndColumn:   a.EndColumn,
+			}
+		}
+		result.Locations = []sarifLocation{loc}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, result)
+}
+
+// MarshalSARIF returns the recorded annotations as a SARIF 2.1.0 document with
+// a single run.
+func (s *SARIFCollector) MarshalSARIF() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := s.ToolName
+	if name == "" {
+		name = defaultSARIFToolName
+	}
+
+	results := make([]sarifResult, len(s.results))
+	copy(results, s.results)
+
+	var run sarifRun
+	run.Tool.Driver.Name = name
+	run.Results = results
+
+	return json.MarshalIndent(&sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+}
+
+// sarifLevel converts an annotation level to a SARIF result level.
+func sarifLevel(level AnnotationLevel) string {
+	switch level {
+	case AnnotationLevelError:
+		return "error"
+	case AnnotationLevelWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name string `json:"name"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifResult struct {
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region *sarifRegion `json:"region,omitempty"`
+	} `json:"physicalLocation"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	EndLine     int `json:"endLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
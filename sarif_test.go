@@ -0,0 +1,126 @@
+// Copyright 2020 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubactions
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+func TestSARIFCollector(t *testing.T) {
+	t.Parallel()
+
+	s := &SARIFCollector{ToolName: "my-scanner"}
+	a := New(WithWriter(io.Discard), WithSARIFCollector(s))
+	a.AnnotateError(Annotation{
+		Title:     "Hardcoded secret",
+		File:      "app.go",
+		Line:      10,
+		EndLine:   12,
+		Col:       5,
+		EndColumn: 20,
+	}, "remove the secret")
+	a.WithFieldsMap(map[string]string{"file": "go.mod"}).AnnotateNotice(Annotation{}, "consider upgrading")
+	a.AnnotateWarning(Annotation{}, "no location")
+
+	got, err := s.MarshalSARIF()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := `{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": [{
+			"tool": {"driver": {"name": "my-scanner"}},
+			"results": [
+				{
+					"level": "error",
+					"message": {"text": "Hardcoded secret: remove the secret"},
+					"locations": [{
+						"physicalLocation": {
+							"artifactLocation": {"uri": "app.go"},
+							"region": {"startLine": 10, "endLine": 12, "startColumn": 5, "endColumn": 20}
+						}
+					}]
+				},
+				{
+					"level": "note",
+					"message": {"text": "consider upgrading"},
+					"locations": [{
+						"physicalLocation": {
+							"artifactLocation": {"uri": "go.mod"}
+						}
+					}]
+				},
+				{
+					"level": "warning",
+					"message": {"text": "no location"}
+				}
+			]
+		}]
+	}`
+
+	var gotCompact, expCompact bytes.Buffer
+	if err := json.Compact(&gotCompact, got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Compact(&expCompact, []byte(exp)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := gotCompact.String(), expCompact.String(); got != want {
+		t.Errorf("expected %s to be %s", got, want)
+	}
+}
+
+func TestSARIFCollector_empty(t *testing.T) {
+	t.Parallel()
+
+	var s SARIFCollector
+	got, err := s.MarshalSARIF()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name string `json:"name"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []any `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(got, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := doc.Version, "2.1.0"; got != want {
+		t.Errorf("expected %q to be %q", got, want)
+	}
+	if got, want := len(doc.Runs), 1; got != want {
+		t.Fatalf("expected %d to be %d", got, want)
+	}
+	if got, want := doc.Runs[0].Tool.Driver.Name, "go-githubactions"; got != want {
+		t.Errorf("expected %q to be %q", got, want)
+	}
+	if doc.Runs[0].Results == nil {
+		t.Errorf("expected results to be an empty array, got null")
+	}
+}
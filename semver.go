@@ -0,0 +1,126 @@
+// Copyright 2020 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubactions
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrEmptyInput is returned, wrapped, by GetInputSemver when the input is not
+// defined or is empty. Use errors.Is to distinguish it from an invalid value.
+var ErrEmptyInput = errors.New("input is empty")
+
+// Version is a semantic version of the form "major.minor.patch" with an
+// optional prerelease, such as "1.2.3" or "1.2.3-rc.1". Build metadata is not
+// supported.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+}
+
+// String returns the version in the form "major.minor.patch[-prerelease]",
+// without a leading "v".
+func (v *Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// parseVersion parses s as a semantic version. A single leading "v" is
+// allowed. Numeric parts must not have leading zeros, and prerelease
+// identifiers must be non-empty and contain only ASCII letters, digits, and
+// hyphens.
+func parseVersion(s string) (*Version, error) {
+	s = strings.TrimPrefix(s, "v")
+	if strings.Contains(s, "+") {
+		return nil, fmt.Errorf("build metadata is not supported")
+	}
+
+	core, pre, hasPre := strings.Cut(s, "-")
+	if hasPre {
+		if err := validatePrerelease(pre); err != nil {
+			return nil, err
+		}
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("expected major.minor.patch")
+	}
+
+	nums := make([]uint64, 3)
+	for i, p := range parts {
+		if !isNumericIdentifier(p) {
+			return nil, fmt.Errorf("invalid numeric part %q", p)
+		}
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid numeric part %q: %w", p, err)
+		}
+		nums[i] = n
+	}
+
+	return &Version{
+		Major:      nums[0],
+		Minor:      nums[1],
+		Patch:      nums[2],
+		Prerelease: pre,
+	}, nil
+}
+
+// validatePrerelease returns an error if pre is not a valid dot-separated list
+// of prerelease identifiers.
+func validatePrerelease(pre string) error {
+	for _, id := range strings.Split(pre, ".") {
+		if id == "" {
+			return fmt.Errorf("empty prerelease identifier")
+		}
+		for _, r := range id {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return fmt.Errorf("invalid prerelease identifier %q", id)
+			}
+		}
+		if isDigits(id) && !isNumericIdentifier(id) {
+			return fmt.Errorf("invalid prerelease identifier %q", id)
+		}
+	}
+	return nil
+}
+
+// isNumericIdentifier reports whether s is a non-empty string of digits
+// without a leading zero, or exactly "0".
+func isNumericIdentifier(s string) bool {
+	return isDigits(s) && (s == "0" || s[0] != '0')
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubactions
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAction_GetInputSemver(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		val    string
+		exp    *Version
+		expErr string
+	}{
+		{
+			name: "valid",
+			val:  "1.2.3",
+			exp:  &Version{Major: 1, Minor: 2, Patch: 3},
+		},
+		{
+			name: "v_prefix",
+			val:  "v0.10.0",
+			exp:  &Version{Major: 0, Minor: 10, Patch: 0},
+		},
+		{
+			name: "prerelease",
+			val:  "1.2.3-rc.1",
+			exp:  &Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"},
+		},
+		{
+			name: "prerelease_hyphen",
+			val:  "2.0.0-alpha-beta.0",
+			exp:  &Version{Major: 2, Minor: 0, Patch: 0, Prerelease: "alpha-beta.0"},
+		},
+		{
+			name:   "empty",
+			val:    "",
+			expErr: `input "version": input is empty`,
+		},
+		{
+			name:   "missing_patch",
+			val:    "1.2",
+			expErr: `input "version" must be a semantic version: "1.2"`,
+		},
+		{
+			name:   "too_many_parts",
+			val:    "1.2.3.4",
+			expErr: `input "version" must be a semantic version: "1.2.3.4"`,
+		},
+		{
+			name:   "leading_zero",
+			val:    "01.2.3",
+			expErr: `invalid numeric part "01"`,
+		},
+		{
+			name:   "not_a_number",
+			val:    "1.x.3",
+			expErr: `invalid numeric part "x"`,
+		},
+		{
+			name:   "range",
+			val:    "^1.2.3",
+			expErr: `invalid numeric part "^1"`,
+		},
+		{
+			name:   "empty_prerelease",
+			val:    "1.2.3-",
+			expErr: "empty prerelease identifier",
+		},
+		{
+			name:   "invalid_prerelease",
+			val:    "1.2.3-rc_1",
+			expErr: `invalid prerelease identifier "rc_1"`,
+		},
+		{
+			name:   "prerelease_leading_zero",
+			val:    "1.2.3-rc.01",
+			expErr: `invalid prerelease identifier "01"`,
+		},
+		{
+			name:   "build_metadata",
+			val:    "1.2.3+build.5",
+			expErr: "build metadata is not supported",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := New(WithWriter(io.Discard), WithGetenv(func(k string) string {
+				if k == "INPUT_VERSION" {
+					return tc.val
+				}
+				return ""
+			}))
+
+			v, err := a.GetInputSemver("version")
+			if err != nil {
+				if tc.expErr == "" {
+					t.Fatal(err)
+				}
+				if got, want := err.Error(), tc.expErr; !strings.Contains(got, want) {
+					t.Errorf("expected %q to contain %q", got, want)
+				}
+			} else if tc.expErr != "" {
+				t.Errorf("expected error %q, got nothing", tc.expErr)
+			}
+
+			if got, want := v, tc.exp; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %#v to be %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestAction_GetInputSemver_emptyIsDistinct(t *testing.T) {
+	t.Parallel()
+
+	a := New(WithWriter(io.Discard), WithGetenv(func(k string) string {
+		if k == "INPUT_VERSION" {
+			return "latest"
+		}
+		return ""
+	}))
+
+	if _, err := a.GetInputSemver("missing"); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("expected %v to be %v", err, ErrEmptyInput)
+	}
+	if _, err := a.GetInputSemver("version"); err == nil || errors.Is(err, ErrEmptyInput) {
+		t.Errorf("expected %v to not be %v", err, ErrEmptyInput)
+	}
+}
+
+func TestVersion_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		v    *Version
+		exp  string
+	}{
+		{name: "release", v: &Version{Major: 1, Minor: 2, Patch: 3}, exp: "1.2.3"},
+		{name: "prerelease", v: &Version{Major: 1, Prerelease: "rc.1"}, exp: "1.0.0-rc.1"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := tc.v.String(), tc.exp; got != want {
+				t.Errorf("expected %q to be %q", got, want)
+			}
+		})
+	}
+}